@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"unicode"
+)
+
+var (
+	annotationsMu sync.Mutex
+	// annotations maps test names to the key/value pairs attached to them, so that any TB value, comparable or not, can be looked up.
+	annotations = map[string][]string{}
+)
+
+// Annotate attaches key/value metadata such as owner, component or ticket to tb. The annotations are appended to every failure reported on tb or its subtests by this package, recorded as observable.annotation.<key> test attributes and passed to observers registered with [Observe], so failures can be routed without parsing test names.
+//
+// kv must contain an even number of elements, alternating keys and values. Calling Annotate again adds to the existing annotations; a subtest's own annotations follow those it inherits. Keys are used in test attribute names and must be non-empty and free of whitespace. Annotate **panics** if kv has odd length or a key is invalid.
+func Annotate(tb testing.TB, kv ...string) {
+	tb.Helper()
+
+	if len(kv)%2 != 0 {
+		panic(fmt.Sprintf("Annotate requires key/value pairs, got %d arguments", len(kv)))
+	}
+	for i := 0; i < len(kv); i += 2 {
+		if kv[i] == "" || strings.IndexFunc(kv[i], unicode.IsSpace) >= 0 {
+			panic(fmt.Sprintf("Annotate: invalid key %q, keys must be non-empty and contain no whitespace", kv[i]))
+		}
+	}
+
+	name := tb.Name()
+
+	annotationsMu.Lock()
+	defer annotationsMu.Unlock()
+
+	if _, ok := annotations[name]; !ok {
+		tb.Cleanup(func() {
+			annotationsMu.Lock()
+			defer annotationsMu.Unlock()
+			delete(annotations, name)
+		})
+	}
+
+	annotations[name] = append(annotations[name], kv...)
+}
+
+// annotationsOf returns the key/value pairs attached to tb and to the tests enclosing it, outermost first.
+func annotationsOf(tb testing.TB) []string {
+	annotationsMu.Lock()
+	defer annotationsMu.Unlock()
+
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	var kv []string
+	name := tb.Name()
+	for i := 0; i <= len(name); i++ {
+		if i == len(name) || name[i] == '/' {
+			kv = append(kv, annotations[name[:i]]...)
+		}
+	}
+
+	return kv
+}
+
+// annotationSuffix renders the annotations attached to tb for inclusion in a failure message, or "" if there are none.
+func annotationSuffix(tb testing.TB) string {
+	kv := annotationsOf(tb)
+	if len(kv) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", kv[i], kv[i+1]))
+	}
+
+	return "\nannotations: " + strings.Join(pairs, " ")
+}
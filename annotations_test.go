@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestAnnotate(t *testing.T) {
+	t.Run("annotated", func(t *testing.T) {
+		spy := testspy.New(t)
+		observable.Annotate(spy, "owner", "payments", "ticket", "PAY-12")
+		observable.Assert(spy, observable.False())
+
+		if len(spy.Messages) != 1 || !strings.HasSuffix(spy.Messages[0], `annotations: owner="payments" ticket="PAY-12"`) {
+			t.Errorf("expected annotations in failure message, got %q", spy.Messages)
+		}
+		if spy.Attrs["observable.annotation.owner"] != "payments" || spy.Attrs["observable.annotation.ticket"] != "PAY-12" {
+			t.Errorf("expected annotations as attributes, got %v", spy.Attrs)
+		}
+	})
+
+	t.Run("other", func(t *testing.T) {
+		other := testspy.New(t)
+		observable.Assert(other, observable.False())
+
+		if strings.Contains(other.Messages[0], "annotations") {
+			t.Errorf("expected annotations to be scoped to their test, got %q", other.Messages[0])
+		}
+	})
+}
+
+func TestAnnotateInherited(t *testing.T) {
+	observable.Annotate(t, "owner", "payments")
+
+	var events []observable.Event
+	defer observable.Observe(func(e observable.Event) {
+		if strings.HasPrefix(e.Test, t.Name()+"/") {
+			events = append(events, e)
+		}
+	})()
+
+	t.Run("sub", func(t *testing.T) {
+		spy := testspy.New(t)
+		observable.Annotate(spy, "component", "refunds")
+		observable.Assert(spy, observable.False())
+
+		if len(spy.Messages) != 1 || !strings.HasSuffix(spy.Messages[0], `annotations: owner="payments" component="refunds"`) {
+			t.Errorf("expected inherited annotations in failure message, got %q", spy.Messages)
+		}
+	})
+
+	if len(events) != 1 || events[0].Annotations["owner"] != "payments" || events[0].Annotations["component"] != "refunds" {
+		t.Errorf("expected annotations in event, got %+v", events)
+	}
+}
+
+// valueTB is a TB passed by value whose dynamic type is not comparable.
+type valueTB struct {
+	*testspy.SpyTB
+	tags []string
+}
+
+func TestAnnotateNonComparableTB(t *testing.T) {
+	spy := testspy.New(t)
+	tb := valueTB{SpyTB: spy, tags: []string{"x"}}
+	observable.Annotate(tb, "owner", "payments")
+	observable.Assert(tb, observable.False())
+
+	if len(spy.Messages) != 1 || !strings.Contains(spy.Messages[0], `owner="payments"`) {
+		t.Errorf("expected annotations for non-comparable TB, got %q", spy.Messages)
+	}
+}
+
+func TestAnnotateOddPanics(t *testing.T) {
+	testspy.ExpectPass(t, observable.Panics(func() { observable.Annotate(t, "owner") }))
+}
+
+func TestAnnotateInvalidKeyPanics(t *testing.T) {
+	for _, key := range []string{"", "team owner", "owner\t", "owner\n"} {
+		func() {
+			defer func() {
+				want := fmt.Sprintf("Annotate: invalid key %q, keys must be non-empty and contain no whitespace", key)
+				if r := recover(); r != want {
+					t.Errorf("got panic %v, want %q", r, want)
+				}
+			}()
+			observable.Annotate(t, key, "payments")
+		}()
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unicode/utf8"
+)
+
+// maxInlineMessage holds the limit set with [SetMaxInlineMessage]; it is accessed atomically so that parallel tests can read it while another test changes it.
+var maxInlineMessage int64 = 16 << 10
+
+// SetMaxInlineMessage sets the size in bytes above which a failure message is not reported inline. Instead, the full message is written to a file in the test's artifact directory and the reported message is truncated and references that file. For equality predicates such as [Equal], [DeepEqual] and [StringEqual], the compared got and want values are also written, each to its own file, so that they can be compared with external tools. The default is 16 KiB; a value <= 0 disables the limit.
+//
+// On Go versions that provide [testing.T.ArtifactDir], files are written there, so they are kept when tests run with -artifacts. Otherwise they are written to a per-test directory under a directory created in [os.TempDir] for the test process, whose path is included in the reported message.
+func SetMaxInlineMessage(n int) {
+	atomic.StoreInt64(&maxInlineMessage, int64(n))
+}
+
+// MaxInlineMessage returns the limit set with [SetMaxInlineMessage].
+func MaxInlineMessage() int {
+	return int(atomic.LoadInt64(&maxInlineMessage))
+}
+
+// artifactDirer is implemented by [testing.TB] on Go versions that support per-test artifact directories.
+type artifactDirer interface {
+	ArtifactDir() string
+}
+
+// attachArtifact returns message unchanged when it fits within [MaxInlineMessage]. Otherwise it writes message to an artifact file, along with the values returned by values if it is not nil, and returns a truncated message that references the files.
+func attachArtifact(tb testing.TB, message string, values func() (got, want any)) string {
+	tb.Helper()
+
+	limit := MaxInlineMessage()
+	if limit <= 0 || len(message) <= limit {
+		return message
+	}
+
+	head := truncateUTF8(message, limit)
+	truncated := sprintf("%s\n... (truncated %d of %d bytes;", head, len(message)-len(head), len(message))
+
+	if values == nil {
+		path, err := writeArtifact(tb, message)
+		if err != nil {
+			return sprintf("%s\n(could not write full failure message to artifact: %v)", message, err)
+		}
+		return sprintf("%s full message written to %s)", truncated, path)
+	}
+
+	got, want := values()
+	paths, err := writeArtifacts(tb, map[string]string{"diff.txt": message, "got.txt": renderArtifact(got), "want.txt": renderArtifact(want)})
+	if err != nil {
+		return sprintf("%s\n(could not write failure artifacts: %v)", message, err)
+	}
+
+	return sprintf("%s full message written to %s, got to %s, want to %s)", truncated, paths["diff.txt"], paths["got.txt"], paths["want.txt"])
+}
+
+// renderArtifact renders a compared value for an artifact file: strings and byte slices verbatim, anything else with %+v.
+func renderArtifact(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprintf("%+v", v)
+	}
+}
+
+// writeArtifact writes data to a new file in tb's artifact directory and returns its path.
+func writeArtifact(tb testing.TB, data string) (string, error) {
+	tb.Helper()
+
+	dir, err := artifactDir(tb)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(dir, "failure-*.txt")
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := f.WriteString(data); err != nil {
+		f.Close()
+		return "", err
+	}
+
+	return f.Name(), f.Close()
+}
+
+// writeArtifacts writes files, keyed by name, to a new directory in tb's artifact directory and returns their paths, keyed by name.
+func writeArtifacts(tb testing.TB, files map[string]string) (map[string]string, error) {
+	tb.Helper()
+
+	dir, err := artifactDir(tb)
+	if err != nil {
+		return nil, err
+	}
+
+	if dir, err = os.MkdirTemp(dir, "failure-*"); err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]string, len(files))
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			return nil, err
+		}
+		paths[name] = path
+	}
+
+	return paths, nil
+}
+
+// artifactDir returns the directory in which artifacts for tb are stored, creating it if necessary.
+func artifactDir(tb testing.TB) (string, error) {
+	tb.Helper()
+
+	if a, ok := tb.(artifactDirer); ok {
+		return a.ArtifactDir(), nil
+	}
+
+	root, err := processArtifactDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(root, sanitizeName(tb.Name()))
+
+	return dir, os.MkdirAll(dir, 0o755)
+}
+
+var (
+	processArtifactOnce sync.Once
+	processArtifactRoot string
+	processArtifactErr  error
+)
+
+// processArtifactDir returns the directory, created on first use, under which [artifactDir] stores artifacts when tb has no artifact directory of its own. It is unique to the test process, so that runs do not mix their artifacts.
+func processArtifactDir() (string, error) {
+	processArtifactOnce.Do(func() {
+		processArtifactRoot, processArtifactErr = os.MkdirTemp("", "observable-artifacts-*")
+	})
+
+	return processArtifactRoot, processArtifactErr
+}
+
+// sanitizeName maps a test name to a string that is safe to use as a single path element.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// truncateUTF8 returns the longest prefix of s that is at most n bytes long and does not split a UTF-8 encoded rune.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestLargeMessageArtifact(t *testing.T) {
+	defer observable.SetMaxInlineMessage(observable.MaxInlineMessage())
+	observable.SetMaxInlineMessage(32)
+
+	got := strings.Repeat("x", 100)
+	p := observable.Equal(got, "y")
+
+	spy := testspy.New(t)
+	observable.Assert(spy, p)
+
+	if len(spy.Messages) != 1 {
+		t.Fatalf("expected one failure message, got %d", len(spy.Messages))
+	}
+
+	msg := spy.Messages[0]
+	_, paths, found := strings.Cut(msg, "full message written to ")
+	if !found {
+		t.Fatalf("expected truncated message to reference artifacts, got %q", msg)
+	}
+	var diff, gotPath, wantPath string
+	if _, err := fmt.Sscanf(strings.TrimSuffix(paths, ")"), "%s got to %s want to %s", &diff, &gotPath, &wantPath); err != nil {
+		t.Fatalf("parsing artifact paths from %q: %v", msg, err)
+	}
+
+	for path, want := range map[string]string{strings.TrimSuffix(diff, ","): p.Message(), strings.TrimSuffix(gotPath, ","): got, wantPath: "y"} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading artifact: %v", err)
+		}
+		if string(data) != want {
+			t.Errorf("expected artifact %s to contain %q, got %q", path, want, data)
+		}
+	}
+}
+
+func TestLargeMessageArtifactWithoutValues(t *testing.T) {
+	defer observable.SetMaxInlineMessage(observable.MaxInlineMessage())
+	observable.SetMaxInlineMessage(32)
+
+	p := observable.ContainsSubstring(strings.Repeat("x", 100), "y")
+
+	spy := testspy.New(t)
+	observable.Assert(spy, p)
+
+	_, path, found := strings.Cut(spy.Messages[0], "full message written to ")
+	if !found {
+		t.Fatalf("expected truncated message to reference artifact, got %q", spy.Messages[0])
+	}
+
+	data, err := os.ReadFile(strings.TrimSuffix(path, ")"))
+	if err != nil {
+		t.Fatalf("reading artifact: %v", err)
+	}
+	if string(data) != p.Message() {
+		t.Errorf("expected artifact to contain full message %q, got %q", p.Message(), data)
+	}
+}
+
+func TestSmallMessageInline(t *testing.T) {
+	p := observable.Equal(1, 2)
+
+	spy := testspy.New(t)
+	observable.Assert(spy, p)
+
+	if len(spy.Messages) != 1 || spy.Messages[0] != p.Message() {
+		t.Errorf("expected inline message %q, got %q", p.Message(), spy.Messages)
+	}
+}
+
+func TestSetMaxInlineMessageDisablesLimit(t *testing.T) {
+	defer observable.SetMaxInlineMessage(observable.MaxInlineMessage())
+	observable.SetMaxInlineMessage(0)
+
+	p := observable.Equal(strings.Repeat("x", 100<<10), "y")
+
+	spy := testspy.New(t)
+	observable.Assert(spy, p)
+
+	if len(spy.Messages) != 1 || spy.Messages[0] != p.Message() {
+		t.Errorf("expected the full message inline with the limit disabled, got %d messages", len(spy.Messages))
+	}
+}
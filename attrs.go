@@ -0,0 +1,159 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// attrer is implemented by [testing.TB] on Go versions that support structured test attributes.
+type attrer interface {
+	Attr(key, value string)
+}
+
+// emitAttrs records metadata about a failed assertion as test attributes when tb supports them, so that tooling can consume it without parsing logs. The attributes are:
+//
+//   - observable.id: the predicate's ID, if any
+//   - observable.name: the predicate's name, if any
+//   - observable.duration: how long evaluating the predicate took
+//   - observable.digest: a short digest of the compared got and want values of equality predicates such as [Equal], or else of the failure message without its location, stable across runs for identical failures
+//   - observable.annotation.<key>: each annotation attached with [Annotate] to the test or its parents
+//
+// detail is the failure message without the prefix identifying the predicate and the assertion's location.
+func emitAttrs(tb testing.TB, p Predicate, elapsed time.Duration, detail string) {
+	a, ok := tb.(attrer)
+	if !ok {
+		return
+	}
+
+	if p.id != "" {
+		a.Attr("observable.id", attrValue(p.id))
+	}
+	if p.name != "" {
+		a.Attr("observable.name", attrValue(p.name))
+	}
+
+	a.Attr("observable.duration", elapsed.String())
+
+	h := sha256.New()
+	if p.values != nil {
+		got, want := p.values()
+		digestValue(h, reflect.ValueOf(got), map[visit]bool{})
+		digestValue(h, reflect.ValueOf(want), map[visit]bool{})
+	} else {
+		io.WriteString(h, detail)
+	}
+	a.Attr("observable.digest", hex.EncodeToString(h.Sum(nil)[:6]))
+
+	kv := annotationsOf(tb)
+	for i := 0; i < len(kv); i += 2 {
+		a.Attr("observable.annotation."+kv[i], attrValue(kv[i+1]))
+	}
+}
+
+// digestValue writes v to the hash h without rendering it, so that digesting large compared values takes constant memory. Byte slices and strings are written directly, map entries in key order; references already being written are skipped to terminate cycles.
+func digestValue(h hash.Hash, v reflect.Value, visited map[visit]bool) {
+	if !v.IsValid() {
+		io.WriteString(h, "<nil>\x00")
+		return
+	}
+
+	io.WriteString(h, v.Type().String())
+	h.Write([]byte{0})
+
+	var buf [8]byte
+	writeUint := func(u uint64) {
+		binary.LittleEndian.PutUint64(buf[:], u)
+		h.Write(buf[:])
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			writeUint(1)
+		} else {
+			writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint(math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		writeUint(math.Float64bits(real(v.Complex())))
+		writeUint(math.Float64bits(imag(v.Complex())))
+	case reflect.String:
+		writeUint(uint64(v.Len()))
+		io.WriteString(h, v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			io.WriteString(h, "nil")
+			return
+		}
+		writeUint(uint64(v.Len()))
+		if v.Type().Elem().Kind() == reflect.Uint8 && v.Kind() == reflect.Slice {
+			h.Write(v.Bytes())
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			digestValue(h, v.Index(i), visited)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			io.WriteString(h, "nil")
+			return
+		}
+		if seen(visited, v) {
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keyLess(keys[i], keys[j]) })
+		writeUint(uint64(len(keys)))
+		for _, k := range keys {
+			digestValue(h, k, visited)
+			digestValue(h, v.MapIndex(k), visited)
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			io.WriteString(h, "nil")
+			return
+		}
+		if seen(visited, v) {
+			return
+		}
+		digestValue(h, v.Elem(), visited)
+	case reflect.Interface:
+		digestValue(h, v.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			digestValue(h, v.Field(i), visited)
+		}
+	}
+}
+
+// seen reports whether the reference v has already been visited, marking it as visited otherwise.
+func seen(visited map[visit]bool, v reflect.Value) bool {
+	k := visit{v.Pointer(), 0, v.Type()}
+	if visited[k] {
+		return true
+	}
+	visited[k] = true
+
+	return false
+}
+
+// attrValue makes s acceptable as a test attribute value, which must not contain line breaks.
+func attrValue(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
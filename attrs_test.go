@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"runtime"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestAttrs(t *testing.T) {
+	spy := testspy.New(t)
+	observable.Assert(spy, observable.True().WithID("ORD-1"))
+
+	if len(spy.Attrs) != 0 {
+		t.Errorf("expected no attributes for passing assertion, got %v", spy.Attrs)
+	}
+
+	observable.Assert(spy, observable.Named("total\npositive", observable.False()).WithID("ORD-1"))
+
+	testspy.ExpectPass(t, observable.MapSubset(spy.Attrs, map[string]string{
+		"observable.id":   "ORD-1",
+		"observable.name": "total positive",
+	}))
+	testspy.ExpectPass(t, observable.ContainsKey(spy.Attrs, "observable.duration"))
+	testspy.ExpectPass(t, observable.StringLength(spy.Attrs["observable.digest"], 12))
+}
+
+func TestAttrsDigest(t *testing.T) {
+	digest := func(assert func(tb testing.TB)) string {
+		spy := testspy.New(t)
+		assert(spy)
+		return spy.Attrs["observable.digest"]
+	}
+
+	equal := digest(func(tb testing.TB) { observable.Assert(tb, observable.Equal(1, 2)) })
+	testspy.ExpectPass(t, observable.Equal(digest(func(tb testing.TB) {
+		observable.Assertf(tb, observable.Named("total", observable.Equal(1, 2)), "totals: %P")
+	}), equal))
+	testspy.ExpectPass(t, observable.Not(observable.Equal(digest(func(tb testing.TB) { observable.Assert(tb, observable.Equal(1, 3)) }), equal)))
+
+	falsy := digest(func(tb testing.TB) { observable.Assert(tb, observable.False()) })
+	testspy.ExpectPass(t, observable.Equal(digest(func(tb testing.TB) {
+		observable.Assert(tb, observable.Named("check", observable.False()))
+	}), falsy))
+}
+
+func TestAttrsDigestStreamsValues(t *testing.T) {
+	got, want := make([]byte, 16<<20), make([]byte, 16<<20)
+	want[len(want)-1] = 1
+
+	spy := testspy.New(t)
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	observable.Assert(spy, observable.BytesEqual(got, want))
+	runtime.ReadMemStats(&after)
+
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 1<<20 {
+		t.Errorf("expected digesting the compared values to take constant memory, allocated %d bytes", alloc)
+	}
+	testspy.ExpectPass(t, observable.StringLength(spy.Attrs["observable.digest"], 12))
+
+	digest := func(got, want any) string {
+		spy := testspy.New(t)
+		observable.Assert(spy, observable.DeepEqual(got, want))
+		return spy.Attrs["observable.digest"]
+	}
+	m := map[int]string{1: "a", 10: "b", 9: "c"}
+	testspy.ExpectPass(t, observable.Equal(digest(m, map[int]string{}), digest(m, map[int]string{})))
+	testspy.ExpectPass(t, observable.Not(observable.Equal(digest(m, map[int]string{}), digest(map[int]string{1: "a"}, map[int]string{}))))
+}
@@ -21,27 +21,70 @@ func Nil(v any) Predicate {
 	}
 
 	return Predicate{
-		ok:  func() bool { return isNil(v) },
-		msg: func() string { return fmt.Sprintf("expected %#v to be nil", v) },
+		ok:   memo(func() bool { return isNil(v) }),
+		msg:  func() string { return sprintf("expected %#v to be nil", v) },
+		neg:  func() string { return sprintf("expected non-nil value, got nil") },
+		desc: func() string { return fmt.Sprintf("%#v is nil", v) },
 	}
 }
 
 // Zero returns a [Predicate] that is ok when v is the zero value of its type.
 func Zero[T comparable](v T) Predicate {
 	return Predicate{
-		ok:  func() bool { return v == *new(T) },
-		msg: func() string { return fmt.Sprintf("expected zero value, got %v", v) },
+		ok:   memo(func() bool { return v == *new(T) }),
+		msg:  func() string { return sprintf("expected zero value, got %v", v) },
+		neg:  func() string { return sprintf("expected non-zero value, got %v", v) },
+		desc: func() string { return fmt.Sprintf("%v is zero", v) },
 	}
 }
 
 // Equal returns a [Predicate] that is ok when got == want.
 func Equal[T comparable](got, want T) Predicate {
 	return Predicate{
-		ok:  func() bool { return got == want },
-		msg: func() string { return fmt.Sprintf("expected %v, got %v", want, got) },
+		ok:     memo(func() bool { return got == want }),
+		msg:    func() string { return sprintf("expected %v, got %v", want, got) },
+		neg:    func() string { return sprintf("expected values to differ, both %v", got) },
+		desc:   func() string { return fmt.Sprintf("%v == %v", got, want) },
+		values: func() (any, any) { return got, want },
 	}
 }
 
+// EqualFunc returns a [Predicate] that is ok when eq(got, want) reports true, for types that are not comparable or that need an approximate or domain-specific equality. EqualFunc **panics** if eq is nil.
+func EqualFunc[T any](got, want T, eq func(got, want T) bool) Predicate {
+	if eq == nil {
+		panic("observable: EqualFunc: nil eq")
+	}
+
+	return Predicate{
+		ok:     memo(func() bool { return eq(got, want) }),
+		msg:    func() string { return sprintf("expected %+v, got %+v", want, got) },
+		neg:    func() string { return sprintf("expected values to differ, got %+v and %+v", got, want) },
+		desc:   func() string { return fmt.Sprintf("%+v equals %+v", got, want) },
+		values: func() (any, any) { return got, want },
+	}
+}
+
+// Same returns a [Predicate] that is ok when got and want point to the same object, as opposed to [Equal], which compares pointers but is easily mistaken for comparing the values they point to. Messages show both addresses and the pointed-to values.
+func Same[T any](got, want *T) Predicate {
+	return Predicate{
+		ok:   memo(func() bool { return got == want }),
+		msg:  func() string { return sprintf("expected same pointer %s, got %s", pointee(want), pointee(got)) },
+		neg:  func() string { return sprintf("expected different pointers, both %s", pointee(got)) },
+		desc: func() string { return fmt.Sprintf("%p is %p", got, want) },
+	}
+}
+
+// NotSame returns a [Predicate] that is ok when got and want do not point to the same object.
+func NotSame[T any](got, want *T) Predicate { return Not(Same(got, want)) }
+
+// pointee renders a pointer as its address followed by the value it points to.
+func pointee[T any](p *T) string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%p (%+v)", p, *p)
+}
+
 // Returns returns a [Predicate] that is ok when f's return value equals want.
 func Returns[T comparable](f func() T, want T) Predicate {
 	var (
@@ -52,24 +95,30 @@ func Returns[T comparable](f func() T, want T) Predicate {
 	eval := func() { once.Do(func() { got = f() }) }
 
 	return Predicate{
-		ok:  func() bool { eval(); return got == want },
-		msg: func() string { eval(); return fmt.Sprintf("expected %v, got %v", want, got) },
+		ok:   func() bool { eval(); return got == want },
+		msg:  func() string { eval(); return sprintf("expected %v, got %v", want, got) },
+		neg:  func() string { eval(); return sprintf("expected result other than %v", want) },
+		desc: func() string { return fmt.Sprintf("returns %v", want) },
 	}
 }
 
 // True returns a Predicate that always is ok.
 func True() Predicate {
 	return Predicate{
-		ok:  func() bool { return true },
-		msg: func() string { return "true" },
+		ok:   func() bool { return true },
+		msg:  func() string { return sprintf("true") },
+		neg:  func() string { return sprintf("false") },
+		desc: func() string { return "true" },
 	}
 }
 
 // False returns a Predicate that always is not ok.
 func False() Predicate {
 	return Predicate{
-		ok:  func() bool { return false },
-		msg: func() string { return "false" },
+		ok:   func() bool { return false },
+		msg:  func() string { return sprintf("false") },
+		neg:  func() string { return sprintf("true") },
+		desc: func() string { return "false" },
 	}
 }
 
@@ -83,6 +132,7 @@ func Any(ps ...Predicate) Predicate {
 	eval := func() {
 		once.Do(func() {
 			for _, p := range ps {
+				p = recoverIfEnabled(p)
 				if !p.Ok() {
 					msgs = append(msgs, p.Message())
 				}
@@ -92,7 +142,12 @@ func Any(ps ...Predicate) Predicate {
 
 	return Predicate{
 		ok:  func() bool { eval(); return len(msgs) < len(ps) },
-		msg: func() string { eval(); return fmt.Sprintf("expected any to be true, all failed: %v", msgs) },
+		msg: func() string { eval(); return sprintf("expected any to be true, all failed: %v", msgs) },
+		neg: func() string {
+			eval()
+			return sprintf("expected none to be true, %d of %d held", len(ps)-len(msgs), len(ps))
+		},
+		desc: func() string { return fmt.Sprintf("any of %d", len(ps)) },
 	}
 }
 
@@ -106,6 +161,7 @@ func All(ps ...Predicate) Predicate {
 	eval := func() {
 		once.Do(func() {
 			for _, p := range ps {
+				p = recoverIfEnabled(p)
 				if !p.Ok() {
 					msgs = append(msgs, p.Message())
 				}
@@ -114,7 +170,151 @@ func All(ps ...Predicate) Predicate {
 	}
 
 	return Predicate{
-		ok:  func() bool { eval(); return len(msgs) == 0 },
-		msg: func() string { eval(); return fmt.Sprintf("expected all to be true, failures: %v", msgs) },
+		ok:   func() bool { eval(); return len(msgs) == 0 },
+		msg:  func() string { eval(); return sprintf("expected all to be true, failures: %v", msgs) },
+		neg:  func() string { eval(); return sprintf("expected some to be false, all %d held", len(ps)) },
+		desc: func() string { return fmt.Sprintf("all of %d", len(ps)) },
+	}
+}
+
+// And returns a [Predicate] that is ok when both p and q are ok. q is not evaluated when p is not ok.
+func (p Predicate) And(q Predicate) Predicate {
+	var (
+		once     sync.Once
+		pOk, qOk bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			p, q = recoverIfEnabled(p), recoverIfEnabled(q)
+			if pOk = p.Ok(); pOk {
+				qOk = q.Ok()
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return pOk && qOk },
+		msg: func() string {
+			eval()
+			if !pOk {
+				return sprintf("expected (%s) and (%s): left failed: %s", p.Describe(), q.Describe(), p.Message())
+			}
+			return sprintf("expected (%s) and (%s): right failed: %s", p.Describe(), q.Describe(), q.Message())
+		},
+		neg: func() string {
+			eval()
+			return sprintf("expected not both (%s) and (%s), both held", p.Describe(), q.Describe())
+		},
+		desc: func() string { return fmt.Sprintf("(%s) and (%s)", p.Describe(), q.Describe()) },
+	}
+}
+
+// Or returns a [Predicate] that is ok when p or q is ok. q is not evaluated when p is ok.
+func (p Predicate) Or(q Predicate) Predicate {
+	var (
+		once     sync.Once
+		pOk, qOk bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			p, q = recoverIfEnabled(p), recoverIfEnabled(q)
+			if pOk = p.Ok(); !pOk {
+				qOk = q.Ok()
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return pOk || qOk },
+		msg: func() string {
+			eval()
+			return sprintf("expected (%s) or (%s): left failed: %s; right failed: %s", p.Describe(), q.Describe(), p.Message(), q.Message())
+		},
+		desc: func() string { return fmt.Sprintf("(%s) or (%s)", p.Describe(), q.Describe()) },
+	}
+}
+
+// Xor returns a [Predicate] that is ok when exactly one of p and q is ok.
+func (p Predicate) Xor(q Predicate) Predicate {
+	var (
+		once     sync.Once
+		pOk, qOk bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			p, q = recoverIfEnabled(p), recoverIfEnabled(q)
+			pOk, qOk = p.Ok(), q.Ok()
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return pOk != qOk },
+		msg: func() string {
+			eval()
+			if pOk {
+				return sprintf("expected exactly one of (%s) and (%s), both held", p.Describe(), q.Describe())
+			}
+			return sprintf("expected exactly one of (%s) and (%s), neither held: left failed: %s; right failed: %s", p.Describe(), q.Describe(), p.Message(), q.Message())
+		},
+		desc: func() string { return fmt.Sprintf("(%s) xor (%s)", p.Describe(), q.Describe()) },
+	}
+}
+
+// Implies returns a [Predicate] that is ok when q is ok or p is not ok. q is not evaluated when p is not ok.
+func (p Predicate) Implies(q Predicate) Predicate {
+	var (
+		once     sync.Once
+		pOk, qOk bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			p, q = recoverIfEnabled(p), recoverIfEnabled(q)
+			if pOk = p.Ok(); pOk {
+				qOk = q.Ok()
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return !pOk || qOk },
+		msg: func() string {
+			eval()
+			return sprintf("expected (%s) to imply (%s): %s", p.Describe(), q.Describe(), q.Message())
+		},
+		desc: func() string { return fmt.Sprintf("(%s) implies (%s)", p.Describe(), q.Describe()) },
+	}
+}
+
+// Map adapts check, a predicate constructor for a value derived from T, into one for T itself, e.g. Map(func(r *http.Response) int { return len(r.Header) }, func(n int) Predicate { return Equal(n, 3) }). extract is called once per evaluation. On failure check's message is followed by the derived value and the original value it was extracted from.
+func Map[T, U any](extract func(T) U, check func(U) Predicate) func(T) Predicate {
+	return func(v T) Predicate {
+		var (
+			once    sync.Once
+			derived U
+			p       Predicate
+		)
+
+		eval := func() { once.Do(func() { derived = extract(v); p = check(derived) }) }
+
+		context := func(msg string) string {
+			return sprintf("%s\n  derived: %+v\n  from:    %+v", msg, derived, v)
+		}
+
+		return Predicate{
+			ok:  func() bool { eval(); return p.Ok() },
+			msg: func() string { eval(); return context(p.Message()) },
+			neg: func() string {
+				eval()
+				if p.neg != nil {
+					return context(p.label("") + p.neg())
+				}
+				return context(sprintf("not: %s", p.Message()))
+			},
+			desc: func() string { eval(); return p.Describe() },
+		}
 	}
 }
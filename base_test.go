@@ -4,6 +4,9 @@ package observable_test
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"renorm.dev/observable"
@@ -56,6 +59,21 @@ func TestEqualChecks(t *testing.T) {
 	testspy.ExpectFail(t, observable.Not(observable.Equal[string])("a", "a"))
 }
 
+func TestEqualFunc(t *testing.T) {
+	approx := func(got, want float64) bool { return got-want < 1e-9 && want-got < 1e-9 }
+
+	testspy.ExpectPass(t, observable.EqualFunc(0.1+0.2, 0.3, approx))
+	testspy.ExpectFail(t, observable.EqualFunc(0.31, 0.3, approx))
+	testspy.ExpectPass(t, observable.EqualFunc([]string{"a"}, []string{"A"}, func(got, want []string) bool { return strings.EqualFold(got[0], want[0]) }))
+	testspy.ExpectFail(t, observable.Not(observable.EqualFunc(0.3, 0.3, approx)))
+
+	if msg := observable.EqualFunc(0.31, 0.3, approx).Message(); msg != "expected 0.3, got 0.31" {
+		t.Errorf("unexpected message %q", msg)
+	}
+
+	testspy.ExpectPass(t, observable.Panics(func() { observable.EqualFunc(1, 1, nil) }))
+}
+
 func TestReturnsChecks(t *testing.T) {
 	// passing
 	count := 0
@@ -140,3 +158,313 @@ func TestNotVariadic(t *testing.T) {
 	testspy.ExpectPass(t, observable.Not(f)("foo", 1, 2, 3))
 	testspy.ExpectFail(t, observable.Not(observable.Not(f))("foo", 1, 2, 3))
 }
+
+func TestPredicateID(t *testing.T) {
+	p := observable.Equal(1, 2).WithID("ORD-042")
+
+	if p.ID() != "ORD-042" {
+		t.Errorf("expected ID ORD-042, got %q", p.ID())
+	}
+
+	spy := testspy.New(t)
+	observable.Assert(spy, p)
+
+	if len(spy.Messages) != 1 || !strings.HasPrefix(spy.Messages[0], "[ORD-042] ") {
+		t.Errorf("expected failure message to carry ID, got %q", spy.Messages)
+	}
+
+	if observable.Equal(1, 2).ID() != "" {
+		t.Error("expected predicate without ID to report empty ID")
+	}
+}
+
+func TestAssertfKeepsID(t *testing.T) {
+	spy := testspy.New(t)
+	observable.Assertf(spy, observable.False().WithID("ORD-042"), "custom %d", 1)
+
+	if len(spy.Messages) != 1 || spy.Messages[0] != "[ORD-042] custom 1" {
+		t.Errorf("expected Assertf message to carry ID, got %q", spy.Messages)
+	}
+}
+
+func TestNotFn(t *testing.T) {
+	testspy.ExpectPass(t, observable.NotFn1(observable.Nil)(1))
+	testspy.ExpectFail(t, observable.NotFn1(observable.Nil)(nil))
+
+	testspy.ExpectPass(t, observable.NotFn2(observable.Equal[string])("a", "b"))
+	testspy.ExpectFail(t, observable.NotFn2(observable.Equal[string])("a", "a"))
+
+	between := func(x, lo, hi int) observable.Predicate { return observable.That(lo <= x && x <= hi) }
+	testspy.ExpectPass(t, observable.NotFn3(between)(5, 1, 3))
+	testspy.ExpectFail(t, observable.NotFn3(between)(2, 1, 3))
+}
+
+func BenchmarkNot(b *testing.B) {
+	b.Run("reflect", func(b *testing.B) {
+		notEqual := observable.Not(observable.Equal[string])
+		for i := 0; i < b.N; i++ {
+			notEqual("a", "b").Ok()
+		}
+	})
+
+	b.Run("generic", func(b *testing.B) {
+		notEqual := observable.NotFn2(observable.Equal[string])
+		for i := 0; i < b.N; i++ {
+			notEqual("a", "b").Ok()
+		}
+	})
+}
+
+func TestNewPredicate(t *testing.T) {
+	testspy.ExpectPass(t, observable.NewPredicate(func() bool { return true }, func() string { return "unused" }))
+	testspy.ExpectFail(t, observable.NewPredicate(func() bool { return false }, func() string { return "custom" }))
+
+	if msg := observable.NewPredicate(func() bool { return false }, func() string { return "custom" }).Message(); msg != "custom" {
+		t.Errorf("expected custom message, got %q", msg)
+	}
+}
+
+func TestNamed(t *testing.T) {
+	p := observable.Named("Equal", observable.Equal(4, 3))
+
+	if p.Name() != "Equal" {
+		t.Errorf("expected name Equal, got %q", p.Name())
+	}
+	if msg := p.Message(); msg != "Equal: expected 3, got 4" {
+		t.Errorf("unexpected message %q", msg)
+	}
+
+	spy := testspy.New(t)
+	observable.Assert(spy, p)
+
+	if len(spy.Messages) != 1 || !regexp.MustCompile(`^Equal \(base_test\.go:\d+\): expected 3, got 4$`).MatchString(spy.Messages[0]) {
+		t.Errorf("expected name and location in failure message, got %q", spy.Messages)
+	}
+}
+
+func TestNotKeepsIDAndName(t *testing.T) {
+	p := observable.Not(observable.Named("truth", observable.True().WithID("ID-1")))
+
+	if p.ID() != "ID-1" || p.Name() != "truth" {
+		t.Errorf("expected negation to keep ID and name, got %q and %q", p.ID(), p.Name())
+	}
+}
+
+func TestNotMessages(t *testing.T) {
+	cases := []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.Not(observable.Equal(5, 5)), "expected values to differ, both 5"},
+		{observable.Not(observable.Not(observable.Equal(5, 4))), "expected 4, got 5"},
+		{observable.Not(observable.Nil(nil)), "expected non-nil value, got nil"},
+		{observable.Not(observable.Not(observable.Not(observable.True()))), "false"},
+		{observable.NotFn2(observable.Equal[int])(1, 1), "expected values to differ, both 1"},
+		{observable.Not(observable.Named("same", observable.Equal(1, 1))), "same: expected values to differ, both 1"},
+		{observable.Not(observable.Length([]int{}, 0)), "not: expected length 0, got 0"},
+		{observable.Not(observable.Named("empty", observable.Length([]int{}, 0))), "empty: not: expected length 0, got 0"},
+		{observable.Not(observable.True().WithID("ID-1")), "[ID-1] false"},
+		{observable.Not(observable.NewPredicate(func() bool { return true }, func() string { return "x" }).WithNegatedMessage(func() string { return "not x" })), "not x"},
+	}
+
+	for _, c := range cases {
+		if got := c.p.Message(); got != c.want {
+			t.Errorf("expected message %q, got %q", c.want, got)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	cases := []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.Length([]int{1}, 3), "len == 3"},
+		{observable.ErrorIs(errFoo, errBar), "err is bar"},
+		{observable.Not(observable.Contains([]int{1}, 2)), "not contains 2"},
+		{observable.Named("custom", observable.True()), "custom"},
+		{observable.NewPredicate(func() bool { return true }, func() string { return "" }), "predicate"},
+	}
+
+	for _, c := range cases {
+		if got := c.p.Describe(); got != c.want {
+			t.Errorf("expected description %q, got %q", c.want, got)
+		}
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	yes, no := observable.True(), observable.False()
+
+	testspy.ExpectPass(t, yes.And(yes))
+	testspy.ExpectFail(t, yes.And(no))
+	testspy.ExpectFail(t, no.And(yes))
+
+	testspy.ExpectPass(t, no.Or(yes))
+	testspy.ExpectPass(t, yes.Or(no))
+	testspy.ExpectFail(t, no.Or(no))
+
+	testspy.ExpectPass(t, yes.Xor(no))
+	testspy.ExpectPass(t, no.Xor(yes))
+	testspy.ExpectFail(t, yes.Xor(yes))
+	testspy.ExpectFail(t, no.Xor(no))
+
+	testspy.ExpectPass(t, yes.Implies(yes))
+	testspy.ExpectPass(t, no.Implies(no))
+	testspy.ExpectFail(t, yes.Implies(no))
+
+	testspy.ExpectPass(t, observable.Not(no.And(observable.That(func() bool { panic("evaluated") }))))
+
+	msg := observable.Equal(1, 1).And(observable.Length([]int{}, 2)).Message()
+	if msg != "expected (1 == 1) and (len == 2): right failed: expected length 2, got 0" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func BenchmarkAssert(b *testing.B) {
+	b.Run("Assert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			observable.Assert(b, observable.Equal(i, i))
+		}
+	})
+
+	b.Run("Assertb", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			observable.Assertb(b, observable.Equal(i, i))
+		}
+	})
+}
+
+func TestEvaluatesOnce(t *testing.T) {
+	calls := 0
+	count := func() { calls++ }
+
+	for _, p := range []observable.Predicate{
+		observable.Panics(func() { count(); panic("boom") }),
+		observable.Errors(func() error { count(); return errFoo }),
+		observable.ErrorsWith(func() error { count(); return errFoo }, errFoo),
+		observable.Not(observable.Panics(func() { count() })),
+	} {
+		calls = 0
+		p.Ok()
+		p.Ok()
+		p.Message()
+		if calls != 1 {
+			t.Errorf("expected subject to be evaluated once, got %d calls", calls)
+		}
+	}
+}
+
+func TestAssertfPredicateVerb(t *testing.T) {
+	spy := testspy.New(t)
+	observable.Assertf(spy, observable.Equal("100%", "50%"), "order %d: %P (%%P is literal)", 7)
+	observable.Expect(spy).Thatf(observable.False(), "%P")
+
+	want := []string{
+		"order 7: " + observable.Equal("100%", "50%").Message() + " (%P is literal)",
+		observable.False().Message(),
+	}
+	if len(spy.Messages) != 2 || spy.Messages[0] != want[0] || spy.Messages[1] != want[1] {
+		t.Errorf("got messages %q, want %q", spy.Messages, want)
+	}
+}
+
+func TestMap(t *testing.T) {
+	type response struct {
+		Status  int
+		Headers []string
+	}
+	headerCount := observable.Map(func(r response) int { return len(r.Headers) }, func(n int) observable.Predicate { return observable.Equal(n, 2) })
+
+	testspy.ExpectPass(t, headerCount(response{200, []string{"a", "b"}}))
+	testspy.ExpectFail(t, headerCount(response{200, []string{"a"}}))
+	testspy.ExpectFail(t, observable.Not(headerCount(response{200, []string{"a", "b"}})))
+
+	want := "expected 2, got 1\n  derived: 1\n  from:    {Status:200 Headers:[a]}"
+	if msg := headerCount(response{200, []string{"a"}}).Message(); msg != want {
+		t.Errorf("got message %q, want %q", msg, want)
+	}
+
+	calls := 0
+	p := observable.Map(func(n int) int { calls++; return n * 2 }, func(n int) observable.Predicate { return observable.Equal(n, 4) })(2)
+	p.Ok()
+	_ = p.Message()
+	if calls != 1 {
+		t.Errorf("extract called %d times, want 1", calls)
+	}
+}
+
+func TestSame(t *testing.T) {
+	a, b := 1, 1
+	pa, pb := &a, &b
+
+	testspy.ExpectPass(t, observable.Same(pa, pa))
+	testspy.ExpectFail(t, observable.Same(pa, pb))
+	testspy.ExpectFail(t, observable.Same(pa, nil))
+	testspy.ExpectPass(t, observable.NotSame(pa, pb))
+	testspy.ExpectFail(t, observable.NotSame(pa, pa))
+
+	want := fmt.Sprintf("expected same pointer %p (1), got %p (1)", pb, pa)
+	if msg := observable.Same(pa, pb).Message(); msg != want {
+		t.Errorf("got message %q, want %q", msg, want)
+	}
+	if msg := observable.NotSame(pa, pa).Message(); msg != fmt.Sprintf("expected different pointers, both %p (1)", pa) {
+		t.Errorf("got message %q", msg)
+	}
+}
+
+func TestNilTB(t *testing.T) {
+	if err := observable.Check(observable.Equal(1, 1)); err != nil {
+		t.Errorf("expected nil error, got %v", err)
+	}
+	if err := observable.Check(observable.Named("port", observable.Equal(1, 2))); err == nil || err.Error() != "port: expected 2, got 1" {
+		t.Errorf("got error %v", err)
+	}
+
+	if !observable.Assert(nil, observable.True()) || !observable.Assertf(nil, observable.True(), "unused") {
+		t.Error("expected nil-TB assertions to pass")
+	}
+	var nilT *testing.T
+	if !observable.Assert(nilT, observable.True()) || !observable.OK(nilT, nil) || !observable.Expect(nilT).That(observable.True()) {
+		t.Error("expected typed-nil-TB assertions to pass")
+	}
+	observable.MustOK(nilT, nil)
+	observable.Expect(nil).Require(observable.True())
+
+	for want, f := range map[string]func(){
+		"expected 2, got 1":               func() { observable.Assert(nil, observable.Equal(1, 2)) },
+		"fixture 7: expected 2, got 1":    func() { observable.Assertf(nil, observable.Equal(1, 2), "fixture %d: %P", 7) },
+		"[FX-1] expected true, got false": func() { observable.Assert(nil, observable.That(false).WithID("FX-1")) },
+		"typed: expected 2, got 1":        func() { observable.Assertf((*testing.T)(nil), observable.Equal(1, 2), "typed: %P") },
+		"bench: expected 2, got 1":        func() { observable.Assertb(nil, observable.Named("bench", observable.Equal(1, 2))) },
+		"expected no error, got: foo":     func() { observable.OK((*testing.T)(nil), errFoo) },
+		"expected no error, got: bar":     func() { observable.MustOK(nil, errBar) },
+		"seed: expected 2, got 1":         func() { observable.With(nil, "seed").That(observable.Equal(1, 2)) },
+		"seed: fixture: expected 2, got 1": func() {
+			observable.With((*testing.T)(nil), "seed").Requiref(observable.Equal(1, 2), "fixture: %P")
+		},
+		"expected zero value, got 1": func() { observable.Got((*testing.B)(nil), 1, observable.Zero[int]) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != want {
+					t.Errorf("got panic %v, want %q", r, want)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestWithError(t *testing.T) {
+	broken := observable.NewPredicate(func() bool { return true }, func() string { return "broken" }).
+		WithError(func() error { return errors.New("broken") })
+
+	testspy.ExpectFail(t, broken)
+	testspy.ExpectFail(t, observable.Not(broken))
+	testspy.ExpectPass(t, observable.Equal(observable.Not(broken).Message(), "broken"))
+
+	healthy := observable.True().WithError(func() error { return nil })
+	testspy.ExpectPass(t, healthy)
+	testspy.ExpectFail(t, observable.Not(healthy))
+}
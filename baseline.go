@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// BaselineFile is the file, relative to the package directory, in which [Baseline] stores expected values.
+var BaselineFile = filepath.Join("testdata", "baselines.json")
+
+// baselineHistory is the number of superseded values kept for each metric.
+const baselineHistory = 10
+
+// baselineEntry is the stored state of one metric: its expected value, the tolerance it is compared with and, oldest first, the values it replaced.
+type baselineEntry struct {
+	Value     float64   `json:"value"`
+	Tolerance float64   `json:"tolerance"`
+	History   []float64 `json:"history,omitempty"`
+}
+
+// baselineMu serialises reads and writes of baseline files by tests of the same package.
+var baselineMu sync.Mutex
+
+// Baseline asserts that got is within the tolerance stored for name in [BaselineFile] of the value stored with it, and records an error on the [testing.TB] when it is not. The failure message shows the drift from the baseline and the values it previously held, so that a gradual regression is visible. A metric with no stored value fails.
+//
+// When tests run with -observable.update, or with an -update bool flag defined by the test package, got is stored as the new baseline together with tolerance instead, and the assertion passes; the value it replaces is added to the history. Value and tolerance are thus versioned together: comparisons use the stored tolerance, and a changed tolerance takes effect once the baseline is updated. Baseline **panics** if tolerance is negative or NaN.
+//
+// As with [Assert], tb may be nil, in which case Baseline **panics** with the failure message on failure.
+func Baseline(tb testing.TB, name string, got, tolerance float64) bool {
+	if !nilTB(tb) {
+		tb.Helper()
+	}
+
+	if !(tolerance >= 0) {
+		panic(fmt.Sprintf("observable: Baseline: invalid tolerance %v", tolerance))
+	}
+
+	baselineMu.Lock()
+	defer baselineMu.Unlock()
+
+	entries, err := readBaselines()
+	if err != nil {
+		return Assert(tb, baselineFailure(func() string { return sprintf("reading baseline file: %v", err) }))
+	}
+
+	if shouldUpdateGolden() {
+		if err := writeBaselines(updateBaseline(entries, name, got, tolerance)); err != nil {
+			return Assert(tb, baselineFailure(func() string { return sprintf("updating baseline file %s: %v", BaselineFile, err) }))
+		}
+		return true
+	}
+
+	entry, ok := entries[name]
+	if !ok {
+		return Assert(tb, baselineFailure(func() string {
+			return sprintf("no baseline for %q in %s (run with -observable.update to record it)", name, BaselineFile)
+		}))
+	}
+
+	return Assert(tb, withinBaseline(name, got, entry))
+}
+
+// baselineFailure returns a [Predicate] that is never ok, for failures to read or write the baseline file.
+func baselineFailure(msg func() string) Predicate {
+	return Predicate{ok: func() bool { return false }, msg: msg}
+}
+
+// withinBaseline returns a [Predicate] that is ok when got is within the stored tolerance of the baseline entry.
+func withinBaseline(name string, got float64, entry baselineEntry) Predicate {
+	tolerance := entry.Tolerance
+
+	return Predicate{
+		ok: memo(func() bool { return math.Abs(got-entry.Value) <= tolerance }),
+		msg: func() string {
+			values := make([]string, 0, len(entry.History)+1)
+			for _, v := range append(entry.History, entry.Value) {
+				values = append(values, fmt.Sprint(v))
+			}
+			return sprintf("expected %s within %v of baseline %v, got %v (drift %+g)\n  history: %s", name, tolerance, entry.Value, got, got-entry.Value, strings.Join(values, " → "))
+		},
+		desc: func() string { return fmt.Sprintf("within %v of baseline %s", tolerance, name) },
+	}
+}
+
+// updateBaseline records got and tolerance for name in entries, moving a different previous value into the history.
+func updateBaseline(entries map[string]baselineEntry, name string, got, tolerance float64) map[string]baselineEntry {
+	entry, ok := entries[name]
+	if ok && entry.Value != got {
+		entry.History = append(entry.History, entry.Value)
+		if n := len(entry.History); n > baselineHistory {
+			entry.History = entry.History[n-baselineHistory:]
+		}
+	}
+	entry.Value, entry.Tolerance = got, tolerance
+	entries[name] = entry
+
+	return entries
+}
+
+// readBaselines reads [BaselineFile], which may not exist yet.
+func readBaselines() (map[string]baselineEntry, error) {
+	entries := map[string]baselineEntry{}
+
+	data, err := os.ReadFile(BaselineFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%s: %w", BaselineFile, err)
+	}
+
+	return entries, nil
+}
+
+// writeBaselines writes entries to [BaselineFile], with sorted keys so that diffs stay small.
+func writeBaselines(entries map[string]baselineEntry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(BaselineFile), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(BaselineFile, append(data, '\n'), 0o644)
+}
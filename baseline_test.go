@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"flag"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestBaseline(t *testing.T) {
+	defer func(file string) { observable.BaselineFile = file }(observable.BaselineFile)
+	observable.BaselineFile = filepath.Join(t.TempDir(), "testdata", "baselines.json")
+
+	spy := testspy.New(t)
+	if observable.Baseline(spy, "latency-ms", 12, 1) || !spy.SpiedOnFailure {
+		t.Fatal("expected missing baseline to fail")
+	}
+	testspy.ExpectPass(t, observable.ContainsSubstring(spy.Messages[0], "-observable.update"))
+
+	update := func(v, tolerance float64) {
+		t.Helper()
+		if err := flag.Set("observable.update", "true"); err != nil {
+			t.Fatal(err)
+		}
+		defer flag.Set("observable.update", "false")
+		if !observable.Baseline(t, "latency-ms", v, tolerance) {
+			t.Fatal("expected update to pass")
+		}
+	}
+	update(10, 0)
+	update(10, 0)
+	update(11, 0)
+	update(12, 1)
+
+	data, err := os.ReadFile(observable.BaselineFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testspy.ExpectPass(t, observable.ContainsSubstring(string(data), `"tolerance": 1`))
+
+	// The stored tolerance applies, not the one passed when comparing.
+	if !observable.Baseline(t, "latency-ms", 12.5, 0) {
+		t.Error("expected value within the stored tolerance to pass")
+	}
+
+	spy = testspy.New(t)
+	if observable.Baseline(spy, "latency-ms", 14, 1) {
+		t.Fatal("expected value outside tolerance to fail")
+	}
+	testspy.ExpectPass(t, observable.ContainsSubstring(spy.Messages[0], "expected latency-ms within 1 of baseline 12, got 14 (drift +2)\n  history: 10 → 11 → 12"))
+
+	if err := os.WriteFile(observable.BaselineFile, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spy = testspy.New(t)
+	if observable.Baseline(spy, "latency-ms", 12, 1) {
+		t.Fatal("expected malformed baseline file to fail")
+	}
+	testspy.ExpectPass(t, observable.HasPrefix(spy.Messages[0], "reading baseline file: "))
+	testspy.ExpectPass(t, observable.StringLength(spy.Attrs["observable.digest"], 12))
+
+	testspy.ExpectPass(t, observable.Panics(func() { observable.Baseline(t, "latency-ms", 12, -1) }))
+	testspy.ExpectPass(t, observable.Panics(func() { observable.Baseline(t, "latency-ms", 12, math.NaN()) }))
+}
+
+func TestBaselineNilTB(t *testing.T) {
+	defer func(file string) { observable.BaselineFile = file }(observable.BaselineFile)
+	observable.BaselineFile = filepath.Join(t.TempDir(), "baselines.json")
+	if err := os.WriteFile(observable.BaselineFile, []byte(`{"latency-ms": {"value": 12, "tolerance": 1}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if !observable.Baseline(nil, "latency-ms", 12.5, 0) {
+		t.Error("expected nil-TB baseline within tolerance to pass")
+	}
+
+	defer func() {
+		if r, _ := recover().(string); !strings.HasPrefix(r, "expected latency-ms within 1 of baseline 12, got 14") {
+			t.Errorf("got panic %q", r)
+		}
+	}()
+	observable.Baseline((*testing.T)(nil), "latency-ms", 14, 0)
+}
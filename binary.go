@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// DecodesAs returns a [Predicate] that is ok when decode succeeds on data and returns a value deeply equal to want (see [DeepEqual]). On failure the decode error or the path to the first difference is reported, together with the start of data.
+func DecodesAs[T any](data []byte, decode func([]byte) (T, error), want T) Predicate {
+	p := decodes(data, func(b []byte) (T, int, error) {
+		v, err := decode(b)
+		return v, -1, err
+	}, want)
+	p.desc = func() string { return fmt.Sprintf("decodes as %T", want) }
+	return p
+}
+
+// DecodesAllAs is like [DecodesAs] for decoders that report how many bytes they consumed, such as varint or TLV readers. It is also ok only if decode consumed all of data; on failure the offset at which decoding stopped or failed is reported with the surrounding bytes.
+func DecodesAllAs[T any](data []byte, decode func([]byte) (v T, n int, err error), want T) Predicate {
+	p := decodes(data, decode, want)
+	p.desc = func() string { return fmt.Sprintf("decodes fully as %T", want) }
+	return p
+}
+
+// decodes implements [DecodesAs] and [DecodesAllAs]; decode reports a negative n when it does not track consumption.
+func decodes[T any](data []byte, decode func([]byte) (T, int, error), want T) Predicate {
+	var (
+		once sync.Once
+		got  T
+		n    int
+		err  error
+		diff string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			if got, n, err = decode(data); err == nil {
+				diff = new(differ).diff(reflect.ValueOf(got), reflect.ValueOf(want))
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return err == nil && diff == "" && (n < 0 || n == len(data)) },
+		msg: func() string {
+			eval()
+			switch {
+			case n > len(data):
+				return sprintf("expected %d bytes to decode as %T, decoder claims to have consumed %d bytes: %s", len(data), want, n, hexExcerpt(data, len(data)))
+			case err != nil && n >= 0:
+				return sprintf("expected %d bytes to decode as %T, failed at byte offset %d: %v: %s", len(data), want, n, err, hexExcerpt(data, n))
+			case err != nil:
+				return sprintf("expected %d bytes to decode as %T: %v: %s", len(data), want, err, hexExcerpt(data, 0))
+			case diff != "":
+				return sprintf("expected %d bytes to decode to the wanted %T\n%s", len(data), want, diff)
+			default:
+				return sprintf("expected decoding to consume all %d bytes, stopped at byte offset %d: %s", len(data), n, hexExcerpt(data, n))
+			}
+		},
+	}
+}
+
+// Uint16At returns a [Predicate] that is ok when the 2 bytes of data at offset, read in the given byte order, equal want. On failure the value found and the surrounding bytes are reported.
+func Uint16At(data []byte, offset int, want uint16, order binary.ByteOrder) Predicate {
+	return uintAt(data, offset, 2, want, order.Uint16)
+}
+
+// Uint32At returns a [Predicate] that is ok when the 4 bytes of data at offset, read in the given byte order, equal want. On failure the value found and the surrounding bytes are reported.
+func Uint32At(data []byte, offset int, want uint32, order binary.ByteOrder) Predicate {
+	return uintAt(data, offset, 4, want, order.Uint32)
+}
+
+// Uint64At returns a [Predicate] that is ok when the 8 bytes of data at offset, read in the given byte order, equal want. On failure the value found and the surrounding bytes are reported.
+func Uint64At(data []byte, offset int, want uint64, order binary.ByteOrder) Predicate {
+	return uintAt(data, offset, 8, want, order.Uint64)
+}
+
+// uintAt implements the fixed-width integer predicates; read decodes exactly size bytes.
+func uintAt[T uint16 | uint32 | uint64](data []byte, offset, size int, want T, read func([]byte) T) Predicate {
+	inRange := offset >= 0 && offset+size <= len(data)
+
+	return Predicate{
+		ok: memo(func() bool { return inRange && read(data[offset:offset+size]) == want }),
+		msg: func() string {
+			if !inRange {
+				return sprintf("expected %d-byte integer %#x at byte offset %d, data is only %d bytes long", size, want, offset, len(data))
+			}
+			return sprintf("expected %d-byte integer %#x at byte offset %d, got %#x: %s", size, want, offset, read(data[offset:offset+size]), hexExcerpt(data, offset))
+		},
+		desc: func() string { return fmt.Sprintf("[%d:%d] == %#x", offset, offset+size, want) },
+	}
+}
+
+// hexExcerpt renders the bytes of data around offset in hex, marking the offset with "▶".
+func hexExcerpt(data []byte, offset int) string {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	start, end := offset-excerptRadius, offset+excerptRadius
+	if start < 0 {
+		start = 0
+	}
+	if end > len(data) {
+		end = len(data)
+	}
+
+	prefix, suffix := "", ""
+	if start > 0 {
+		prefix = "…"
+	}
+	if end < len(data) {
+		suffix = "…"
+	}
+
+	return prefix + hex.EncodeToString(data[start:offset]) + "▶" + hex.EncodeToString(data[offset:end]) + suffix
+}
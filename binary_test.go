@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+// overConsuming is a broken decoder that claims to consume more bytes than it was given.
+func overConsuming([]byte) (int, int, error) { return 1, 100, nil }
+
+// uvarint adapts binary.Uvarint to the decoder shape of DecodesAllAs.
+func uvarint(b []byte) (uint64, int, error) {
+	v, n := binary.Uvarint(b)
+	if n <= 0 {
+		return 0, -n, errors.New("bad varint")
+	}
+	return v, n, nil
+}
+
+type header struct {
+	Version uint8
+	Flags   []byte
+}
+
+func decodeHeader(b []byte) (header, error) {
+	if len(b) < 1 {
+		return header{}, errors.New("short header")
+	}
+	return header{Version: b[0], Flags: b[1:]}, nil
+}
+
+func TestDecodesAs(t *testing.T) {
+	testspy.ExpectPass(t, observable.DecodesAs([]byte{1, 0xa, 0xb}, decodeHeader, header{1, []byte{0xa, 0xb}}))
+	testspy.ExpectFail(t, observable.DecodesAs([]byte{2, 0xa}, decodeHeader, header{1, []byte{0xa}}))
+	testspy.ExpectFail(t, observable.DecodesAs(nil, decodeHeader, header{}))
+
+	testspy.ExpectPass(t, observable.DecodesAllAs([]byte{0xac, 0x02}, uvarint, 300))
+	testspy.ExpectFail(t, observable.DecodesAllAs([]byte{0xac, 0x02}, uvarint, 301))
+	testspy.ExpectFail(t, observable.DecodesAllAs([]byte{0xac, 0x02, 0xff}, uvarint, 300))
+	testspy.ExpectFail(t, observable.DecodesAllAs([]byte{0xac}, uvarint, 300))
+	testspy.ExpectFail(t, observable.DecodesAllAs([]byte{1, 2}, overConsuming, 1))
+
+	for _, tc := range []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.DecodesAs([]byte{2, 0xa}, decodeHeader, header{1, []byte{0xa}}), "expected 2 bytes to decode to the wanted observable_test.header\n.Version: 2 != 1"},
+		{observable.DecodesAs(nil, decodeHeader, header{}), "expected 0 bytes to decode as observable_test.header: short header: ▶"},
+		{observable.DecodesAllAs([]byte{0xac, 0x02, 0xff}, uvarint, 300), "expected decoding to consume all 3 bytes, stopped at byte offset 2: ac02▶ff"},
+		{observable.DecodesAllAs([]byte{0xac}, uvarint, 300), "expected 1 bytes to decode as uint64, failed at byte offset 0: bad varint: ▶ac"},
+		{observable.DecodesAllAs([]byte{1, 2}, overConsuming, 1), "expected 2 bytes to decode as int, decoder claims to have consumed 100 bytes: 0102▶"},
+		{observable.DecodesAllAs([]byte{1, 2}, func([]byte) (int, int, error) { return 0, 100, errors.New("bad") }, 1), "expected 2 bytes to decode as int, decoder claims to have consumed 100 bytes: 0102▶"},
+	} {
+		if msg := tc.p.Message(); msg != tc.want {
+			t.Errorf("got message %q, want %q", msg, tc.want)
+		}
+	}
+}
+
+func TestUintAt(t *testing.T) {
+	data := []byte{0xca, 0xfe, 0xba, 0xbe, 0, 0, 0, 0, 0, 0, 0, 1}
+
+	testspy.ExpectPass(t, observable.Uint16At(data, 0, 0xcafe, binary.BigEndian))
+	testspy.ExpectPass(t, observable.Uint16At(data, 0, 0xfeca, binary.LittleEndian))
+	testspy.ExpectPass(t, observable.Uint32At(data, 0, 0xcafebabe, binary.BigEndian))
+	testspy.ExpectFail(t, observable.Uint32At(data, 0, 0xcafebabe, binary.LittleEndian))
+	testspy.ExpectPass(t, observable.Uint64At(data, 4, 1, binary.BigEndian))
+	testspy.ExpectFail(t, observable.Uint64At(data, 5, 1, binary.BigEndian))
+	testspy.ExpectFail(t, observable.Uint32At(data, -1, 0, binary.BigEndian))
+
+	for _, tc := range []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.Uint32At(data, 0, 0xcafebabe, binary.LittleEndian), "expected 4-byte integer 0xcafebabe at byte offset 0, got 0xbebafeca: ▶cafebabe0000000000000001"},
+		{observable.Uint64At(data, 5, 1, binary.BigEndian), "expected 8-byte integer 0x1 at byte offset 5, data is only 12 bytes long"},
+	} {
+		if msg := tc.p.Message(); msg != tc.want {
+			t.Errorf("got message %q, want %q", msg, tc.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sync"
+)
+
+// compareChunk is the number of bytes read from each input at a time by the streaming comparisons. Inputs that fit in a single chunk are reported with a full [contentDiff].
+const compareChunk = 64 << 10
+
+// BytesEqual returns a [Predicate] that is ok when got and want are identical. On failure inputs of up to 64 KiB are reported as by [FileEqual]; larger ones only by their first differing byte offset, so that multi-gigabyte inputs do not produce multi-gigabyte messages.
+func BytesEqual(got, want []byte) Predicate {
+	return Predicate{
+		ok: memo(func() bool { return bytes.Equal(got, want) }),
+		msg: func() string {
+			if len(got) <= compareChunk && len(want) <= compareChunk {
+				return sprintf("expected bytes to be equal\n%s", contentDiff(want, got))
+			}
+			return sprintf("expected bytes to be equal\n%s", byteDiff(want, got))
+		},
+		neg: func() string {
+			return sprintf("expected bytes to differ, both are %d bytes long and identical", len(got))
+		},
+		desc:   func() string { return "bytes equal" },
+		values: func() (any, any) { return got, want },
+	}
+}
+
+// ReaderEqual returns a [Predicate] that is ok when got and want yield identical contents. Both are read in chunks, once, on first evaluation, and reading stops at the first difference, so inputs of any size are compared in constant memory. On failure the first differing byte offset is reported.
+func ReaderEqual(got, want io.Reader) Predicate {
+	var (
+		once sync.Once
+		res  streamComparison
+	)
+
+	eval := func() { once.Do(func() { res = compareStreams(want, got) }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return res.err == nil && res.equal },
+		msg: func() string {
+			eval()
+			if res.err != nil {
+				return sprintf("expected readers to be equal: %v", res.err)
+			}
+			return sprintf("expected readers to be equal\n%s", res.diff())
+		},
+		desc: func() string { return "contents equal" },
+	}
+}
+
+// streamComparison is the result of [compareStreams].
+type streamComparison struct {
+	equal bool
+	err   error
+
+	// whole holds want and got in full when both fit in the first chunk.
+	whole *[2][]byte
+
+	// offset is that of the first differing byte; want and got hold the bytes of each input from start, the preceding 16-byte boundary.
+	offset, start int64
+	want, got     []byte
+}
+
+// compareStreams reads want and got in chunks of [compareChunk] bytes until they differ, either of them fails or both end.
+func compareStreams(want, got io.Reader) (res streamComparison) {
+	wb, gb := make([]byte, compareChunk), make([]byte, compareChunk)
+
+	var off int64
+	for {
+		wn, err := readChunk(want, wb)
+		if err != nil {
+			res.err = errorf("reading want: %w", err)
+			return res
+		}
+		gn, err := readChunk(got, gb)
+		if err != nil {
+			res.err = errorf("reading got: %w", err)
+			return res
+		}
+
+		if off == 0 && wn < compareChunk && gn < compareChunk {
+			res.whole = &[2][]byte{wb[:wn], gb[:gn]}
+		}
+
+		if bytes.Equal(wb[:wn], gb[:gn]) {
+			if wn < compareChunk {
+				res.equal = true
+				return res
+			}
+			off += int64(wn)
+			continue
+		}
+
+		i := 0
+		for i < wn && i < gn && wb[i] == gb[i] {
+			i++
+		}
+		start := i - i%16
+		res.offset, res.start = off+int64(i), off+int64(start)
+		res.want, res.got = window(want, wb[:wn], start), window(got, gb[:gn], start)
+		return res
+	}
+}
+
+// readChunk fills buf from r, returning the number of bytes read; reaching the end of r is not an error.
+func readChunk(r io.Reader, buf []byte) (int, error) {
+	n, err := io.ReadFull(r, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		err = nil
+	}
+	return n, err
+}
+
+// window returns the 32 bytes of the input from start in chunk, reading the bytes that lie past the end of a full chunk from r.
+func window(r io.Reader, chunk []byte, start int) []byte {
+	if start >= len(chunk) {
+		return nil
+	}
+
+	w := append([]byte(nil), chunk[start:]...)
+	if len(w) < 32 && len(chunk) == compareChunk {
+		extra := make([]byte, 32-len(w))
+		n, _ := readChunk(r, extra)
+		w = append(w, extra[:n]...)
+	}
+	if len(w) > 32 {
+		w = w[:32]
+	}
+
+	return w
+}
+
+// diff describes where the compared streams differ.
+func (c streamComparison) diff() string {
+	if c.whole != nil {
+		return contentDiff(c.whole[0], c.whole[1])
+	}
+
+	dump := func(b []byte) string {
+		if len(b) == 0 {
+			return "<end of input>"
+		}
+		return hex.EncodeToString(b)
+	}
+
+	return sprintf("first difference at byte offset %d\nwant[%d:]: %s\ngot[%d:]:  %s", c.offset, c.start, dump(c.want), c.start, dump(c.got))
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+// large returns n bytes of a repeating pattern with b[at] flipped, or unmodified if at is negative.
+func large(n, at int) []byte {
+	b := bytes.Repeat([]byte("0123456789abcdef"), n/16)
+	if at >= 0 {
+		b[at] ^= 0xff
+	}
+	return b
+}
+
+func TestBytesEqual(t *testing.T) {
+	testspy.ExpectPass(t, observable.BytesEqual([]byte("abc"), []byte("abc")))
+	testspy.ExpectFail(t, observable.BytesEqual([]byte("abc"), []byte("abd")))
+	testspy.ExpectPass(t, observable.BytesEqual(large(1<<20, -1), large(1<<20, -1)))
+	testspy.ExpectFail(t, observable.BytesEqual(large(1<<20, 700000), large(1<<20, -1)))
+
+	testspy.ExpectPass(t, observable.ContainsSubstring(observable.BytesEqual([]byte("a\nb\n"), []byte("a\nc\n")).Message(), "+   2: b"))
+	testspy.ExpectPass(t, observable.ContainsSubstring(observable.BytesEqual(large(1<<20, 700000), large(1<<20, -1)).Message(), "first difference at byte offset 700000"))
+}
+
+func TestReaderEqual(t *testing.T) {
+	const n = 1 << 20
+
+	testspy.ExpectPass(t, observable.ReaderEqual(bytes.NewReader(large(n, -1)), bytes.NewReader(large(n, -1))))
+	testspy.ExpectFail(t, observable.ReaderEqual(bytes.NewReader(large(n, 700000)), bytes.NewReader(large(n, -1))))
+	testspy.ExpectFail(t, observable.ReaderEqual(bytes.NewReader(large(n, -1)[:n-1]), bytes.NewReader(large(n, -1))))
+	testspy.ExpectPass(t, observable.ReaderEqual(strings.NewReader(""), strings.NewReader("")))
+	testspy.ExpectFail(t, observable.ReaderEqual(io.MultiReader(strings.NewReader("ab"), failingReader{}), strings.NewReader("ab")))
+
+	// The difference lies just before a chunk boundary, so the reported window spans two chunks.
+	msg := observable.ReaderEqual(bytes.NewReader(large(n, 65530)), bytes.NewReader(large(n, -1))).Message()
+	want := "expected readers to be equal\n" +
+		"first difference at byte offset 65530\n" +
+		"want[65520:]: 3031323334353637383961626364656630313233343536373839616263646566\n" +
+		"got[65520:]:  303132333435363738399e626364656630313233343536373839616263646566"
+	if msg != want {
+		t.Errorf("got message\n%s\nwant\n%s", msg, want)
+	}
+}
+
+// failingReader is a reader that always fails.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("disk on fire") }
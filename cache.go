@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"sync"
+)
+
+// cachedResult holds the predicate built for a [Cached] key and the outcome of evaluating it.
+type cachedResult struct {
+	build sync.Once
+	p     Predicate
+
+	eval sync.Once
+	ok   bool
+}
+
+var cached sync.Map // of key → *cachedResult
+
+// Cached returns a [Predicate] whose outcome is shared by every Cached call with the same key in the test binary. build is called only by the first Cached call with key, and its predicate evaluated only the first time any of them is evaluated; later evaluations reuse the result. This suits expensive, shared checks such as "schema compiles" or "server healthy" that would otherwise be repeated in many subtests. The returned predicate otherwise behaves as the built one, with its messages, negation, ID, name and description.
+//
+// key must be comparable, as for a map key.
+func Cached(key any, build func() Predicate) Predicate {
+	v, _ := cached.LoadOrStore(key, new(cachedResult))
+	r := v.(*cachedResult)
+	r.build.Do(func() { r.p = build() })
+
+	p := r.p
+	p.ok = func() bool {
+		r.eval.Do(func() { r.ok = r.p.Ok() })
+		return r.ok
+	}
+	// The shared outcome must not be bypassed by a context-aware evaluation.
+	p.okCtx = nil
+
+	return p
+}
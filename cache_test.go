@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestCached(t *testing.T) {
+	type key struct{ name string }
+
+	var probes int32
+	healthy := func() observable.Predicate {
+		atomic.AddInt32(&probes, 1)
+		return observable.True()
+	}
+
+	for i := 0; i < 5; i++ {
+		t.Run("row", func(t *testing.T) {
+			t.Parallel()
+			testspy.ExpectPass(t, observable.Cached(key{"healthy"}, healthy))
+		})
+	}
+
+	t.Cleanup(func() {
+		if n := atomic.LoadInt32(&probes); n != 1 {
+			t.Errorf("expected one evaluation, got %d", n)
+		}
+	})
+
+	testspy.ExpectFail(t, observable.Cached(key{"broken"}, observable.False))
+	testspy.ExpectFail(t, observable.Cached(key{"broken"}, observable.True))
+}
+
+func TestCachedKeepsPredicate(t *testing.T) {
+	type key struct{ name string }
+
+	p := observable.Cached(key{"identified"}, func() observable.Predicate {
+		return observable.Named("health", observable.Equal(1, 1).WithID("ID-1"))
+	})
+
+	if p.ID() != "ID-1" || p.Name() != "health" || p.Describe() != "health" {
+		t.Errorf("expected ID and name to be kept, got %q, %q", p.ID(), p.Name())
+	}
+
+	n := observable.Not(p)
+	testspy.ExpectFail(t, n)
+	if msg := n.Message(); msg != "[ID-1] health: expected values to differ, both 1" {
+		t.Errorf("unexpected negated message %q", msg)
+	}
+}
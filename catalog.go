@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Catalog translates the templates used to build failure messages, allowing them to be localized or adapted to an organization's style guide.
+//
+// Templates are the default English fmt-style format strings used by this package, such as "expected %v, got %v". A replacement must consume the same arguments; explicit argument indexes (e.g. "got %[2]v, expected %[1]v") can be used to reorder them.
+type Catalog interface {
+	// Translate returns the template to use in place of format. Returning format unchanged keeps the default text.
+	Translate(format string) string
+}
+
+// CatalogMap is a [Catalog] backed by a map from default templates to their replacements. Templates missing from the map are left unchanged.
+type CatalogMap map[string]string
+
+// Translate implements [Catalog].
+func (m CatalogMap) Translate(format string) string {
+	if t, ok := m[format]; ok {
+		return t
+	}
+	return format
+}
+
+var catalog atomic.Value // of catalogHolder
+
+// catalogHolder wraps a Catalog so that atomic.Value always stores the same concrete type.
+type catalogHolder struct{ c Catalog }
+
+// SetCatalog installs c as the package-wide message catalog. Passing nil restores the default messages.
+func SetCatalog(c Catalog) {
+	catalog.Store(catalogHolder{c})
+}
+
+// sprintf formats a failure message like [fmt.Sprintf] after translating format through the installed [Catalog].
+func sprintf(format string, args ...any) string {
+	return fmt.Sprintf(translate(format), args...)
+}
+
+// errorf is like [fmt.Errorf] but translates format through the installed [Catalog], for errors whose text is reported in failure messages.
+func errorf(format string, args ...any) error {
+	return fmt.Errorf(translate(format), args...)
+}
+
+// translate returns the template the installed [Catalog] uses in place of format.
+func translate(format string) string {
+	if h, ok := catalog.Load().(catalogHolder); ok && h.c != nil {
+		return h.c.Translate(format)
+	}
+
+	return format
+}
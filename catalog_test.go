@@ -0,0 +1,46 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+
+	"renorm.dev/observable"
+)
+
+func TestCatalog(t *testing.T) {
+	defer observable.SetCatalog(nil)
+
+	observable.SetCatalog(observable.CatalogMap{
+		"expected %v, got %v":        "erwartet %v, erhalten %v",
+		"not: %s":                    "nicht: %s",
+		"expected length %d, got %d": "Länge %[2]d statt %[1]d",
+		"expected function to panic": "Panik erwartet",
+		"missing key %#v":            "Schlüssel %#v fehlt",
+		"invalid YAML in got: %w":    "ungültiges YAML in got: %w",
+	})
+
+	cases := []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.Equal(4, 3), "erwartet 3, erhalten 4"},
+		{observable.Length([]int{1}, 2), "Länge 1 statt 2"},
+		{observable.Not(observable.Length([]int{}, 0)), "nicht: Länge 0 statt 0"},
+		{observable.HasPrefix("a", "b"), `expected "a" to have prefix "b"`},
+		{observable.Panics(func() {}), "Panik erwartet"},
+		{observable.MapEqual(map[string]int{}, map[string]int{"a": 1}), "expected maps to be equal:\n  Schlüssel \"a\" fehlt\nwant: map[string]int{\"a\":1}\ngot:  map[string]int{}"},
+		{observable.YAMLEqual("a: [", "a: 1"), "expected YAML documents to be equal: ungültiges YAML in got: line 1: unterminated flow collection, expected ']'"},
+	}
+
+	for _, c := range cases {
+		if got := c.p.Message(); got != c.want {
+			t.Errorf("expected message %q, got %q", c.want, got)
+		}
+	}
+
+	observable.SetCatalog(nil)
+	if got := observable.Equal(4, 3).Message(); got != "expected 3, got 4" {
+		t.Errorf("expected default message after reset, got %q", got)
+	}
+}
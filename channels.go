@@ -2,12 +2,290 @@
 
 package observable
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+	"time"
+)
 
 // ChanLength returns a [Predicate] that is ok when len(c) == want (buffered channels only).
 func ChanLength[T any](c chan T, want int) Predicate {
+	var (
+		once sync.Once
+		n    int
+	)
+
+	eval := func() { once.Do(func() { n = len(c) }) }
+
+	return Predicate{
+		ok:   func() bool { eval(); return n == want },
+		msg:  func() string { eval(); return sprintf("expected channel buffer length %d, got %d", want, n) },
+		desc: func() string { return fmt.Sprintf("len == %d", want) },
+	}
+}
+
+// ChanCap returns a [Predicate] that is ok when cap(c) == want, i.e. c was made with a buffer of want elements.
+func ChanCap[T any](c chan T, want int) Predicate {
+	return Predicate{
+		ok:   memo(func() bool { return cap(c) == want }),
+		msg:  func() string { return sprintf("expected channel capacity %d, got %d", want, cap(c)) },
+		desc: func() string { return fmt.Sprintf("cap == %d", want) },
+	}
+}
+
+// Receives returns a [Predicate] that is ok when a value equal to want is received from c within timeout.
+//
+// The receive happens at most once, on first evaluation, and consumes the value.
+func Receives[T comparable](c <-chan T, want T, timeout time.Duration) Predicate {
+	var (
+		once     sync.Once
+		got      T
+		received bool
+		closed   bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+
+			select {
+			case got, received = <-c:
+				closed = !received
+			case <-timer.C:
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return received && got == want },
+		msg: func() string {
+			eval()
+			switch {
+			case closed:
+				return sprintf("expected to receive %v, channel was closed", want)
+			case !received:
+				return sprintf("expected to receive %v within %v, nothing received", want, timeout)
+			default:
+				return sprintf("expected to receive %v, got %v", want, got)
+			}
+		},
+	}
+}
+
+// Closed returns a [Predicate] that is ok when c is closed, or becomes closed within timeout.
+//
+// The receive happens at most once, on first evaluation; a value received instead of the close is consumed.
+func Closed[T any](c <-chan T, timeout time.Duration) Predicate {
+	var (
+		once     sync.Once
+		got      T
+		received bool
+		closed   bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+
+			select {
+			case got, received = <-c:
+				closed = !received
+			case <-timer.C:
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return closed },
+		msg: func() string {
+			eval()
+			if received {
+				return sprintf("expected channel to be closed, received %v", got)
+			}
+			return sprintf("expected channel to be closed within %v", timeout)
+		},
+	}
+}
+
+// Blocked returns a [Predicate] that is ok when a receive from c would block, i.e. c is open and has no value ready.
+//
+// The check happens at most once, on first evaluation; a value that is ready is consumed.
+func Blocked[T any](c <-chan T) Predicate {
+	var (
+		once     sync.Once
+		got      T
+		received bool
+		blocked  bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			select {
+			case got, received = <-c:
+			default:
+				blocked = true
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return blocked },
+		msg: func() string {
+			eval()
+			if received {
+				return sprintf("expected receive to block, received %v", got)
+			}
+			return sprintf("expected receive to block, channel was closed")
+		},
+	}
+}
+
+// ChanDrainEquals returns a [Predicate] that is ok when the values received from c until it is closed equal want, in order. c must be closed within timeout; values received before the timeout are still compared so the report shows where the streams diverged.
+//
+// The receives happen at most once, on first evaluation, and consume the values.
+func ChanDrainEquals[T comparable](c <-chan T, want []T, timeout time.Duration) Predicate {
+	var (
+		once     sync.Once
+		got      []T
+		timedOut bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+
+			for {
+				select {
+				case v, ok := <-c:
+					if !ok {
+						return
+					}
+					got = append(got, v)
+				case <-timer.C:
+					timedOut = true
+					return
+				}
+			}
+		})
+	}
+
+	diverged := func() int {
+		for i := range got {
+			if i >= len(want) || got[i] != want[i] {
+				return i
+			}
+		}
+		if len(got) < len(want) {
+			return len(got)
+		}
+		return -1
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return !timedOut && diverged() < 0 },
+		msg: func() string {
+			eval()
+
+			end := "channel closed"
+			if timedOut {
+				end = sprintf("channel not closed within %v", timeout)
+			}
+
+			i := diverged()
+			switch {
+			case i < 0:
+				return sprintf("expected channel to yield %v and close, got all values but %s", want, end)
+			case i >= len(want):
+				return sprintf("expected channel to yield %v, got %v: extra value %v at index %d (%s)", want, got, got[i], i, end)
+			case i >= len(got):
+				return sprintf("expected channel to yield %v, got %v: missing %v at index %d (%s)", want, got, want[i], i, end)
+			default:
+				return sprintf("expected channel to yield %v, got %v: first difference at index %d: got %v, want %v (%s)", want, got, i, got[i], want[i], end)
+			}
+		},
+		desc: func() string { return fmt.Sprintf("drains to %v", want) },
+	}
+}
+
+// SendsWithoutBlocking returns a [Predicate] that is ok when v can be sent on c without blocking, i.e. c has free buffer space or a receiver is waiting. On failure the buffer state is reported.
+//
+// The send happens at most once, on first evaluation. Sending on a closed channel fails rather than panicking.
+func SendsWithoutBlocking[T any](c chan<- T, v T) Predicate {
+	var (
+		once     sync.Once
+		sent     bool
+		closed   bool
+		n, capac int
+	)
+
+	eval := func() {
+		once.Do(func() {
+			defer func() {
+				if recover() != nil {
+					closed = true
+				}
+			}()
+			n, capac = len(c), cap(c)
+			select {
+			case c <- v:
+				sent = true
+			default:
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return sent },
+		msg: func() string {
+			eval()
+			if closed {
+				return sprintf("expected to send %v without blocking, channel was closed", v)
+			}
+			return sprintf("expected to send %v without blocking, send would block (buffer %d/%d)", v, n, capac)
+		},
+		neg: func() string {
+			return sprintf("expected send of %v to block, it succeeded (buffer %d/%d)", v, n, capac)
+		},
+		desc: func() string { return "sends without blocking" },
+	}
+}
+
+// ReceivesWithoutBlocking returns a [Predicate] that is ok when a value can be received from c without blocking, i.e. c has a buffered value or a sender is waiting. On failure the buffer state is reported.
+//
+// The receive happens at most once, on first evaluation, and consumes the value.
+func ReceivesWithoutBlocking[T any](c <-chan T) Predicate {
+	var (
+		once     sync.Once
+		got      T
+		received bool
+		closed   bool
+		capac    int
+	)
+
+	eval := func() {
+		once.Do(func() {
+			capac = cap(c)
+			select {
+			case got, received = <-c:
+				closed = !received
+			default:
+			}
+		})
+	}
+
 	return Predicate{
-		ok:  func() bool { return len(c) == want },
-		msg: func() string { return fmt.Sprintf("expected channel buffer length %d, got %d", want, len(c)) },
+		ok: func() bool { eval(); return received },
+		msg: func() string {
+			eval()
+			if closed {
+				return sprintf("expected to receive without blocking, channel was closed")
+			}
+			return sprintf("expected to receive without blocking, receive would block (buffer 0/%d)", capac)
+		},
+		neg:  func() string { return sprintf("expected receive to block, received %v", got) },
+		desc: func() string { return "receives without blocking" },
 	}
 }
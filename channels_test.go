@@ -4,6 +4,7 @@ package observable_test
 
 import (
 	"testing"
+	"time"
 
 	"renorm.dev/observable"
 	"renorm.dev/observable/internal/testspy"
@@ -16,3 +17,104 @@ func TestChannelAsserts(t *testing.T) {
 	testspy.ExpectPass(t, observable.ChanLength(ch, 2))
 	testspy.ExpectFail(t, observable.ChanLength(ch, 5))
 }
+
+func TestChannelReceives(t *testing.T) {
+	ch := make(chan int, 5)
+	ch <- 1
+	ch <- 2
+
+	testspy.ExpectPass(t, observable.Receives(ch, 1, time.Second))
+	testspy.ExpectFail(t, observable.Receives(ch, 1, time.Second))
+	testspy.ExpectFail(t, observable.Receives(ch, 1, time.Millisecond))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ch <- 3
+	}()
+	testspy.ExpectPass(t, observable.Receives(ch, 3, time.Second))
+
+	close(ch)
+	testspy.ExpectFail(t, observable.Receives(ch, 0, time.Second))
+}
+
+func TestChannelClosed(t *testing.T) {
+	ch := make(chan int, 1)
+	testspy.ExpectFail(t, observable.Closed(ch, time.Millisecond))
+
+	ch <- 1
+	testspy.ExpectFail(t, observable.Closed(ch, time.Second))
+
+	go close(ch)
+	testspy.ExpectPass(t, observable.Closed(ch, time.Second))
+}
+
+func TestChannelBlocked(t *testing.T) {
+	ch := make(chan int, 1)
+	testspy.ExpectPass(t, observable.Blocked(ch))
+
+	ch <- 1
+	testspy.ExpectFail(t, observable.Blocked(ch))
+
+	close(ch)
+	testspy.ExpectFail(t, observable.Blocked(ch))
+}
+
+func TestChanDrainEquals(t *testing.T) {
+	stream := func(closed bool, vs ...int) <-chan int {
+		c := make(chan int, len(vs))
+		for _, v := range vs {
+			c <- v
+		}
+		if closed {
+			go func() { time.Sleep(time.Millisecond); close(c) }()
+		}
+		return c
+	}
+
+	testspy.ExpectPass(t, observable.ChanDrainEquals(stream(true, 1, 2, 3), []int{1, 2, 3}, time.Second))
+	testspy.ExpectPass(t, observable.ChanDrainEquals(stream(true), nil, time.Second))
+	testspy.ExpectFail(t, observable.ChanDrainEquals(stream(true, 1, 2), []int{1, 2, 3}, time.Second))
+	testspy.ExpectFail(t, observable.ChanDrainEquals(stream(true, 1, 2, 3, 4), []int{1, 2, 3}, time.Second))
+	testspy.ExpectFail(t, observable.ChanDrainEquals(stream(false, 1, 2, 3), []int{1, 2, 3}, 10*time.Millisecond))
+
+	p := observable.ChanDrainEquals(stream(true, 1, 5, 3), []int{1, 2, 3}, time.Second)
+	testspy.ExpectFail(t, p)
+	if want := "expected channel to yield [1 2 3], got [1 5 3]: first difference at index 1: got 5, want 2 (channel closed)"; p.Message() != want {
+		t.Errorf("got message %q, want %q", p.Message(), want)
+	}
+}
+
+func TestNonBlocking(t *testing.T) {
+	c := make(chan int, 1)
+
+	testspy.ExpectFail(t, observable.ReceivesWithoutBlocking(c))
+	testspy.ExpectPass(t, observable.SendsWithoutBlocking(c, 1))
+	testspy.ExpectFail(t, observable.SendsWithoutBlocking(c, 2))
+	testspy.ExpectPass(t, observable.ReceivesWithoutBlocking(c))
+
+	c <- 1
+	if msg := observable.SendsWithoutBlocking(c, 2).Message(); msg != "expected to send 2 without blocking, send would block (buffer 1/1)" {
+		t.Errorf("got message %q", msg)
+	}
+	<-c
+	if msg := observable.ReceivesWithoutBlocking(c).Message(); msg != "expected to receive without blocking, receive would block (buffer 0/1)" {
+		t.Errorf("got message %q", msg)
+	}
+
+	close(c)
+	testspy.ExpectFail(t, observable.SendsWithoutBlocking(c, 3))
+	testspy.ExpectFail(t, observable.ReceivesWithoutBlocking(c))
+	if msg := observable.SendsWithoutBlocking(c, 3).Message(); msg != "expected to send 3 without blocking, channel was closed" {
+		t.Errorf("got message %q", msg)
+	}
+}
+
+func TestChanCap(t *testing.T) {
+	testspy.ExpectPass(t, observable.ChanCap(make(chan int, 4), 4))
+	testspy.ExpectPass(t, observable.ChanCap(make(chan int), 0))
+	testspy.ExpectFail(t, observable.ChanCap(make(chan int, 4), 2))
+
+	if msg, want := observable.ChanCap(make(chan int), 2).Message(), "expected channel capacity 2, got 0"; msg != want {
+		t.Errorf("got message %q, want %q", msg, want)
+	}
+}
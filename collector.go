@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Failure is an assertion failure recorded by a [Collector].
+type Failure struct {
+	// Test is the name of the test or subtest that made the assertion.
+	Test string
+	// Message is the failure message, as [Assert] would report it.
+	Message string
+}
+
+// Collector gathers soft assertions: [Collector.Check] records failures instead of failing the test, and [Collector.Report] reports them all at once. A Collector may be shared by parallel subtests; each failure is attributed to the subtest that produced it.
+type Collector struct {
+	mu       sync.Mutex
+	failures []Failure
+}
+
+// NewCollector returns an empty [Collector].
+func NewCollector() *Collector { return &Collector{} }
+
+// Check evaluates p and, when it is not ok, records the failure against tb's test name. It does not fail tb. Observers registered with [Observe] are notified, and test attributes recorded, as for [Assert]. Check is safe for concurrent use.
+func (c *Collector) Check(tb testing.TB, p Predicate) bool {
+	tb.Helper()
+
+	ok, message := verdict(tb, p, "", func(p Predicate) string { return p.msg() })
+	emit(tb, p.id, ok, message)
+	if ok {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = append(c.failures, Failure{Test: tb.Name(), Message: message})
+
+	return false
+}
+
+// Failures returns the failures recorded so far, ordered by test name and, within a test, in the order they were recorded.
+func (c *Collector) Failures() []Failure {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	failures := append([]Failure(nil), c.failures...)
+	sort.SliceStable(failures, func(i, j int) bool { return failures[i].Test < failures[j].Test })
+
+	return failures
+}
+
+// Report records a single error on tb listing every failure recorded so far, grouped by test name, and returns whether there were none. Call it once the checks are done, e.g. after the parallel subtests of a t.Run group have completed.
+func (c *Collector) Report(tb testing.TB) bool {
+	tb.Helper()
+
+	failures := c.Failures()
+	if len(failures) == 0 {
+		return true
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d soft assertions failed:", len(failures))
+	for i, f := range failures {
+		if i == 0 || f.Test != failures[i-1].Test {
+			fmt.Fprintf(&sb, "\n%s:", f.Test)
+		}
+		fmt.Fprintf(&sb, "\n  %s", strings.ReplaceAll(f.Message, "\n", "\n  "))
+	}
+
+	tb.Error(sb.String())
+
+	return false
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestCollector(t *testing.T) {
+	c := observable.NewCollector()
+
+	t.Run("group", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			i := i
+			t.Run(fmt.Sprint(i), func(t *testing.T) {
+				t.Parallel()
+				for j := 0; j < 10; j++ {
+					c.Check(t, observable.That(i%5 != 0 || j > 0))
+				}
+			})
+		}
+	})
+
+	failures := c.Failures()
+	if len(failures) != 4 {
+		t.Fatalf("got %d failures, want 4: %v", len(failures), failures)
+	}
+	for i, want := range []string{"0", "10", "15", "5"} {
+		if f := failures[i]; f.Test != t.Name()+"/group/"+want || f.Message != "expected true, got false" {
+			t.Errorf("unexpected failure %d: %+v", i, f)
+		}
+	}
+
+	spy := testspy.New(t)
+	if c.Report(spy) || len(spy.Messages) != 1 {
+		t.Fatalf("expected a single reported error, got %q", spy.Messages)
+	}
+	want := "4 soft assertions failed:\n" + t.Name() + "/group/0:\n  expected true, got false\n" + t.Name() + "/group/10:\n  expected true, got false\n"
+	testspy.ExpectPass(t, observable.HasPrefix(spy.Messages[0], want))
+
+	spy = testspy.New(t)
+	if !observable.NewCollector().Report(spy) || spy.SpiedOnFailure {
+		t.Error("expected empty collector to report nothing")
+	}
+
+	spy = testspy.New(t)
+	if observable.NewCollector().Check(spy, observable.False().WithID("SOFT-1")) || spy.SpiedOnFailure {
+		t.Error("expected Check to record the failure without failing the test")
+	}
+	testspy.ExpectPass(t, observable.Equal(spy.Attrs["observable.id"], "SOFT-1"))
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+// Package compat adapts matchers and assertions from other testing libraries into [observable.Predicate]s, so that existing checks can be reused while migrating.
+//
+// To keep observable dependency-free, the adapters are defined in terms of small interfaces that the third-party types satisfy structurally; neither gomega nor testify is imported.
+package compat
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"renorm.dev/observable"
+)
+
+// Matcher is the method set of a gomega matcher (types.GomegaMatcher).
+type Matcher interface {
+	Match(actual interface{}) (success bool, err error)
+	FailureMessage(actual interface{}) (message string)
+	NegatedFailureMessage(actual interface{}) (message string)
+}
+
+// FromGomega returns a [observable.Predicate] that is ok when matcher matches actual, e.g. FromGomega(gomega.HaveLen(3), items). Its negation (see [observable.Not]) fails with the matcher's NegatedFailureMessage. If matching itself fails, both the predicate and its negation fail with the matcher's error.
+func FromGomega(matcher Matcher, actual interface{}) observable.Predicate {
+	var (
+		once sync.Once
+		ok   bool
+		err  error
+	)
+
+	eval := func() { once.Do(func() { ok, err = matcher.Match(actual) }) }
+
+	return observable.NewPredicate(
+		func() bool { eval(); return ok && err == nil },
+		func() string {
+			eval()
+			if err != nil {
+				return fmt.Sprintf("matcher error: %v", err)
+			}
+			return matcher.FailureMessage(actual)
+		},
+	).WithNegatedMessage(func() string { return matcher.NegatedFailureMessage(actual) }).WithError(func() error { eval(); return err })
+}
+
+// TestingT is the interface testify's assertion functions report failures through (assert.TestingT).
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// FromTestifyAssertion returns a [observable.Predicate] that runs check with the assertions object built by newAssertions, which records the failures of the testify assertions check makes, and is ok when none failed. newAssertions is assert.New:
+//
+//	compat.FromTestifyAssertion(assert.New, func(a *assert.Assertions) {
+//		a.Equal(42, answer)
+//		a.NotEmpty(items)
+//	})
+//
+// check is run once, on first evaluation. Failure messages reported by the assertions are joined to form the predicate's message. FromTestifyAssertion **panics** if T is an interface the recording [TestingT] does not implement.
+func FromTestifyAssertion[T, A any](newAssertions func(T) A, check func(A)) observable.Predicate {
+	var (
+		once sync.Once
+		rec  recorder
+	)
+
+	t, ok := any(&rec).(T)
+	if !ok {
+		panic(fmt.Sprintf("compat.FromTestifyAssertion: %T does not implement %s", &rec, reflect.TypeOf((*T)(nil)).Elem()))
+	}
+
+	eval := func() { once.Do(func() { check(newAssertions(t)) }) }
+
+	return observable.NewPredicate(
+		func() bool { eval(); return len(rec.failures) == 0 },
+		func() string { eval(); return strings.Join(rec.failures, "\n") },
+	)
+}
+
+// recorder is a [TestingT] that records failure messages.
+type recorder struct {
+	mu       sync.Mutex
+	failures []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = append(r.failures, strings.TrimSpace(fmt.Sprintf(format, args...)))
+}
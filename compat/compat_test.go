@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package compat_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/compat"
+	"renorm.dev/observable/internal/testspy"
+)
+
+// haveLen mimics gomega's HaveLen matcher.
+type haveLen int
+
+func (m haveLen) Match(actual interface{}) (bool, error) {
+	s, ok := actual.([]int)
+	if !ok {
+		return false, errors.New("HaveLen matcher expects a []int")
+	}
+	return len(s) == int(m), nil
+}
+
+func (m haveLen) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n    %v\nto have length %d", actual, m)
+}
+
+func (m haveLen) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n    %v\nnot to have length %d", actual, m)
+}
+
+// equal mimics testify's assert.Equal.
+func equal(t compat.TestingT, expected, actual interface{}) bool {
+	if expected != actual {
+		t.Errorf("\n\tError: Not equal:\n\texpected: %v\n\tactual  : %v\n", expected, actual)
+		return false
+	}
+	return true
+}
+
+func TestFromGomega(t *testing.T) {
+	testspy.ExpectPass(t, compat.FromGomega(haveLen(2), []int{1, 2}))
+	testspy.ExpectFail(t, compat.FromGomega(haveLen(3), []int{1, 2}))
+	testspy.ExpectFail(t, compat.FromGomega(haveLen(1), "x"))
+	testspy.ExpectFail(t, observable.Not(compat.FromGomega(haveLen(1), "x")))
+	testspy.ExpectPass(t, observable.Equal(observable.Not(compat.FromGomega(haveLen(1), "x")).Message(), "matcher error: HaveLen matcher expects a []int"))
+
+	if msg := compat.FromGomega(haveLen(1), "x").Message(); msg != "matcher error: HaveLen matcher expects a []int" {
+		t.Errorf("unexpected message: %q", msg)
+	}
+
+	negated := observable.Not(compat.FromGomega(haveLen(2), []int{1, 2}))
+	testspy.ExpectFail(t, negated)
+	if msg := negated.Message(); msg != "Expected\n    [1 2]\nnot to have length 2" {
+		t.Errorf("unexpected negated message: %q", msg)
+	}
+}
+
+// assertions mimics testify's assert.Assertions.
+type assertions struct{ t compat.TestingT }
+
+func newAssertions(t compat.TestingT) *assertions { return &assertions{t} }
+
+func (a *assertions) Equal(expected, actual interface{}) bool { return equal(a.t, expected, actual) }
+
+func TestFromTestifyAssertion(t *testing.T) {
+	testspy.ExpectPass(t, compat.FromTestifyAssertion(newAssertions, func(a *assertions) { a.Equal(1, 1) }))
+
+	p := compat.FromTestifyAssertion(newAssertions, func(a *assertions) {
+		a.Equal(1, 2)
+		a.Equal("a", "a")
+		a.Equal("a", "b")
+	})
+	testspy.ExpectFail(t, p)
+	if want := "Error: Not equal:\n\texpected: 1\n\tactual  : 2\nError: Not equal:\n\texpected: a\n\tactual  : b"; p.Message() != want {
+		t.Errorf("got message %q, want %q", p.Message(), want)
+	}
+
+	testspy.ExpectPass(t, observable.Panics(func() {
+		compat.FromTestifyAssertion(func(t interface{ FailNow() }) *assertions { return nil }, func(*assertions) {})
+	}))
+}
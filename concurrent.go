@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"sync"
+	"time"
+)
+
+// HoldsThroughout returns a [Predicate] that is ok when the invariant built by invariant holds on every sample taken while during runs. The invariant is sampled when during starts, every interval while it runs, and once more after it returns; invariant is called from a goroutine other than the one running during.
+//
+// On failure the first violating sample is reported with its timestamp and offset from the start.
+func HoldsThroughout(invariant func() Predicate, during func(), interval time.Duration) Predicate {
+	var (
+		once      sync.Once
+		samples   int
+		violation Predicate
+		violated  bool
+		start, at time.Time
+	)
+
+	eval := func() {
+		once.Do(func() {
+			sample := func() {
+				if violated {
+					return
+				}
+				samples++
+				if p := invariant(); !p.Ok() {
+					violation, violated, at = p, true, time.Now()
+				}
+			}
+
+			done := make(chan struct{})
+			start = time.Now()
+			go func() {
+				defer close(done)
+				during()
+			}()
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				sample()
+				select {
+				case <-done:
+					sample()
+					return
+				case <-ticker.C:
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return !violated },
+		msg: func() string {
+			eval()
+			if !violated {
+				return sprintf("invariant held for all %d samples", samples)
+			}
+			return sprintf("invariant violated at sample %d, %s (+%v): %s", samples, at.Format(time.RFC3339Nano), at.Sub(start), violation.Message())
+		},
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestHoldsThroughout(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		from, to = 100, 0
+	)
+
+	total := func() observable.Predicate {
+		mu.Lock()
+		defer mu.Unlock()
+		return observable.Equal(from+to, 100)
+	}
+
+	transfer := func(atomic bool) func() {
+		return func() {
+			for i := 0; i < 20; i++ {
+				mu.Lock()
+				from--
+				if atomic {
+					to++
+				}
+				mu.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				if !atomic {
+					mu.Lock()
+					to++
+					mu.Unlock()
+				}
+			}
+		}
+	}
+
+	testspy.ExpectPass(t, observable.HoldsThroughout(total, transfer(true), time.Millisecond))
+	testspy.ExpectFail(t, observable.HoldsThroughout(total, transfer(false), 100*time.Microsecond))
+}
@@ -0,0 +1,276 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+var (
+	uncoveredMu sync.Mutex
+	// uncoveredTests holds the requests of tests that passed after calling [ExplainUncovered], for [ReportUncovered].
+	uncoveredTests []uncoveredRequest
+)
+
+// uncoveredRequest is a test's request to report the uncovered blocks of funcs.
+type uncoveredRequest struct {
+	test  string
+	funcs []string
+}
+
+// ExplainUncovered is a developer-mode aid for finding assertions that look thorough but only exercise the happy path. When tb passes having made at least one passing assertion, the blocks of the functions named in funcs that this run of the test binary left uncovered are reported by [ReportUncovered], grouped by function. Functions are named as in the source, e.g. "Parse" or "Decoder.Decode".
+//
+// The report uses the coverage profile the current run writes, so the tests must be run with go test -coverprofile and TestMain must call ReportUncovered; otherwise ExplainUncovered fails the test. It **panics** if funcs is empty.
+func ExplainUncovered(tb testing.TB, funcs ...string) {
+	tb.Helper()
+
+	if len(funcs) == 0 {
+		panic("observable: ExplainUncovered requires the names of the functions to explain")
+	}
+	if testing.CoverMode() == "" || coverProfile() == "" {
+		tb.Error("ExplainUncovered: this run writes no coverage profile; run go test with -coverprofile")
+		return
+	}
+
+	var passed int32
+	name := tb.Name()
+	remove := Observe(func(e Event) {
+		if e.Test == name && e.Passed {
+			atomic.AddInt32(&passed, 1)
+		}
+	})
+
+	tb.Cleanup(func() {
+		remove()
+		if atomic.LoadInt32(&passed) == 0 || tb.Failed() {
+			return
+		}
+
+		uncoveredMu.Lock()
+		defer uncoveredMu.Unlock()
+		uncoveredTests = append(uncoveredTests, uncoveredRequest{name, funcs})
+	})
+}
+
+// ReportUncovered prints the reports requested with [ExplainUncovered] to standard output once the coverage profile of the current run has been written, and returns code, or 1 if the profile cannot be read. Call it from TestMain with the result of m.Run, which writes the profile:
+//
+//	func TestMain(m *testing.M) { os.Exit(observable.ReportUncovered(m.Run())) }
+func ReportUncovered(code int) int {
+	uncoveredMu.Lock()
+	requests := uncoveredTests
+	uncoveredTests = nil
+	uncoveredMu.Unlock()
+
+	for _, r := range requests {
+		report, err := uncoveredReport(coverProfile(), r.funcs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ExplainUncovered: reading the coverage profile of this run: %v\n", err)
+			if code == 0 {
+				code = 1
+			}
+			return code
+		}
+		if report != "" {
+			fmt.Printf("%s: assertions passed without exercising:\n%s\n", r.test, report)
+		}
+	}
+
+	return code
+}
+
+// coverProfile returns the path of the coverage profile the current run writes, as set by go test -coverprofile, or "" if there is none.
+func coverProfile() string {
+	f := flag.Lookup("test.coverprofile")
+	if f == nil || f.Value.String() == "" {
+		return ""
+	}
+
+	path := f.Value.String()
+	if dir := flag.Lookup("test.outputdir"); dir != nil && dir.Value.String() != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(dir.Value.String(), path)
+	}
+
+	return path
+}
+
+// coverBlock is a block of statements from a coverage profile.
+type coverBlock struct {
+	file               string
+	startLine, endLine int
+	count              int
+}
+
+// uncoveredReport lists the uncovered blocks of funcs in profile, one function per line.
+func uncoveredReport(profile string, funcs []string) (string, error) {
+	blocks, err := readCoverProfile(profile)
+	if err != nil {
+		return "", err
+	}
+
+	want := map[string]bool{}
+	for _, f := range funcs {
+		want[f] = true
+	}
+
+	// Blocks are reported once per function, in source order.
+	uncovered := map[string][]string{}
+	var order []string
+	fset := token.NewFileSet()
+	parsed := map[string]*ast.File{}
+
+	for _, b := range blocks {
+		if b.count > 0 {
+			continue
+		}
+
+		path, ok := resolveSourcePath(b.file)
+		if !ok {
+			continue
+		}
+		f, ok := parsed[path]
+		if !ok {
+			if f, err = parser.ParseFile(fset, path, nil, parser.SkipObjectResolution); err != nil {
+				return "", err
+			}
+			parsed[path] = f
+		}
+
+		fn := enclosingFunc(fset, f, b.startLine)
+		if fn == "" || (len(want) > 0 && !want[fn]) {
+			continue
+		}
+		if _, ok := uncovered[fn]; !ok {
+			order = append(order, fn)
+		}
+		uncovered[fn] = append(uncovered[fn], fmt.Sprintf("%s:%d-%d", filepath.Base(path), b.startLine, b.endLine))
+	}
+
+	var sb strings.Builder
+	for _, fn := range order {
+		fmt.Fprintf(&sb, "  %s: %s\n", fn, strings.Join(uncovered[fn], ", "))
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+// readCoverProfile parses a coverage profile in the format written by go test -coverprofile. Blocks are sorted by file and position; blocks listed several times (e.g. by multiple packages' tests) have their counts summed.
+func readCoverProfile(path string) ([]coverBlock, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	type key struct {
+		file string
+		pos  string
+	}
+	index := map[key]int{}
+
+	var blocks []coverBlock
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if n == 1 && strings.HasPrefix(line, "mode:") || line == "" {
+			continue
+		}
+
+		// Lines read "file:startLine.startCol,endLine.endCol numStmts count".
+		colon := strings.LastIndexByte(line, ':')
+		fields := strings.Fields(line[colon+1:])
+		if colon < 0 || len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: malformed coverage line %q", path, n, line)
+		}
+		var b coverBlock
+		b.file = line[:colon]
+		if _, err := fmt.Sscanf(fields[0], "%d.%d,%d.%d", &b.startLine, new(int), &b.endLine, new(int)); err != nil {
+			return nil, fmt.Errorf("%s:%d: malformed block %q", path, n, fields[0])
+		}
+		if b.count, err = strconv.Atoi(fields[2]); err != nil {
+			return nil, fmt.Errorf("%s:%d: malformed count %q", path, n, fields[2])
+		}
+
+		k := key{b.file, fields[0]}
+		if i, ok := index[k]; ok {
+			blocks[i].count += b.count
+			continue
+		}
+		index[k] = len(blocks)
+		blocks = append(blocks, b)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if blocks[i].file != blocks[j].file {
+			return blocks[i].file < blocks[j].file
+		}
+		return blocks[i].startLine < blocks[j].startLine
+	})
+
+	return blocks, nil
+}
+
+// resolveSourcePath maps a file named in a coverage profile, which is usually an import path such as "example.com/mod/pkg/file.go", to a file on disk relative to the working directory, which go test sets to the package directory.
+func resolveSourcePath(file string) (string, bool) {
+	if _, err := os.Stat(file); err == nil {
+		return file, true
+	}
+
+	parts := strings.Split(file, "/")
+	for i := 1; i < len(parts); i++ {
+		candidate := filepath.Join(parts[i:]...)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// enclosingFunc returns the name of the function declared in f that contains line, with the receiver type for methods, e.g. "Decoder.Decode".
+func enclosingFunc(fset *token.FileSet, f *ast.File, line int) string {
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fset.Position(fn.Pos()).Line > line || fset.Position(fn.End()).Line < line {
+			continue
+		}
+		if fn.Recv == nil || len(fn.Recv.List) == 0 {
+			return fn.Name.Name
+		}
+
+		typ := fn.Recv.List[0].Type
+		for {
+			switch t := typ.(type) {
+			case *ast.StarExpr:
+				typ = t.X
+				continue
+			case *ast.IndexExpr:
+				typ = t.X
+				continue
+			case *ast.IndexListExpr:
+				typ = t.X
+				continue
+			case *ast.Ident:
+				return t.Name + "." + fn.Name.Name
+			}
+			return fn.Name.Name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+const coverSource = `package parse
+
+func Parse(s string) int {
+	if s == "" {
+		return 0
+	}
+	return len(s)
+}
+
+type Decoder struct{}
+
+func (d *Decoder) Decode(s string) int {
+	if s == "" {
+		return -1
+	}
+	return 1
+}
+`
+
+const coverTest = `package parse
+
+import (
+	"os"
+	"testing"
+
+	"renorm.dev/observable"
+)
+
+func TestMain(m *testing.M) { os.Exit(observable.ReportUncovered(m.Run())) }
+
+func TestParse(t *testing.T) {
+	observable.ExplainUncovered(t, "Parse", "Decoder.Decode")
+	observable.Assert(t, observable.Equal(Parse("ab"), 2))
+	observable.Assert(t, observable.Equal(new(Decoder).Decode(""), -1))
+}
+
+func TestNoAssertions(t *testing.T) {
+	observable.ExplainUncovered(t, "Parse")
+}
+`
+
+func TestExplainUncovered(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs a test binary")
+	}
+	root, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":        "module example.com/parse\n\ngo 1.18\n\nrequire renorm.dev/observable v0.0.0\n\nreplace renorm.dev/observable => " + root + "\n",
+		"parse.go":      coverSource,
+		"parse_test.go": coverTest,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command("go", "test", "-count=1", "-v", "-coverprofile="+filepath.Join(dir, "cover.out"), ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go test: %v\n%s", err, out)
+	}
+
+	testspy.ExpectPass(t, observable.ContainsSubstring(string(out), "TestParse: assertions passed without exercising:\n  Parse: parse.go:5-6\n  Decoder.Decode: parse.go:16-16\n"))
+	testspy.ExpectFail(t, observable.ContainsSubstring(string(out), "TestNoAssertions:"))
+}
+
+func TestExplainUncoveredWithoutProfile(t *testing.T) {
+	if testing.CoverMode() != "" {
+		t.Skip("this run writes a coverage profile")
+	}
+
+	spy := testspy.New(t)
+	observable.ExplainUncovered(spy, "Parse")
+
+	if len(spy.Messages) != 1 || !strings.Contains(spy.Messages[0], "run go test with -coverprofile") {
+		t.Errorf("expected a clear failure without a coverage profile, got %q", spy.Messages)
+	}
+	testspy.ExpectPass(t, observable.Panics(func() { observable.ExplainUncovered(spy) }))
+}
@@ -0,0 +1,328 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DeepEqual returns a [Predicate] that is ok when got and want are deeply equal, following the rules of [reflect.DeepEqual] as adjusted by opts, e.g. types compared with a custom function with [Comparer]. On failure the path to the first difference is reported, e.g. ".Config.Timeout: 5s != 10s".
+func DeepEqual(got, want any, opts ...Option) Predicate {
+	d := newDiffer(opts)
+
+	var (
+		once sync.Once
+		diff string
+	)
+
+	eval := func() { once.Do(func() { diff = d.diff(reflect.ValueOf(got), reflect.ValueOf(want)) }) }
+
+	return Predicate{
+		ok:     func() bool { eval(); return diff == "" },
+		msg:    func() string { eval(); return sprintf("expected values to be deeply equal\n%s", diff) },
+		desc:   func() string { return "deeply equal" },
+		values: func() (any, any) { return got, want },
+	}
+}
+
+// differ finds the first difference between two values.
+type differ struct {
+	visited map[visit]bool
+
+	// The remaining fields are set by [Option]s.
+
+	// all makes walk record every differing struct field in found rather than stopping at the first.
+	all   bool
+	found []string
+	// ignore holds field names and dotted field paths (e.g. "Owner.ID") to skip.
+	ignore           map[string]bool
+	ignoreUnexported bool
+	// emptyEqual makes nil and empty slices and maps compare equal.
+	emptyEqual bool
+	comparers  map[reflect.Type]func(got, want reflect.Value) bool
+}
+
+// visit identifies a pair of references already being compared, to terminate cycles.
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+// diff returns a description of the first difference between got and want in the form "path: got != want", or "" if they are deeply equal.
+func (d *differ) diff(got, want reflect.Value) string {
+	d.visited = map[visit]bool{}
+	return d.walk("", got, want)
+}
+
+func (d *differ) walk(path string, got, want reflect.Value) string {
+	mismatch := func(g, w any) string {
+		p := path
+		if p == "" {
+			p = "(root)"
+		}
+		return fmt.Sprintf("%s: %v != %v", p, g, w)
+	}
+
+	if !got.IsValid() || !want.IsValid() {
+		if got.IsValid() == want.IsValid() {
+			return ""
+		}
+		return mismatch(describe(got), describe(want))
+	}
+
+	if got.Type() != want.Type() {
+		return mismatch(fmt.Sprintf("(%v)", got.Type()), fmt.Sprintf("(%v)", want.Type()))
+	}
+
+	if eq, ok := d.comparers[got.Type()]; ok && got.CanInterface() {
+		if eq(got, want) {
+			return ""
+		}
+		return mismatch(got, want)
+	}
+
+	switch got.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if d.emptyEqual && got.Kind() != reflect.Ptr && got.Len() == 0 && want.Len() == 0 {
+			return ""
+		}
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() == want.IsNil() {
+				return ""
+			}
+			return mismatch(describe(got), describe(want))
+		}
+		if got.Kind() != reflect.Slice && got.Pointer() == want.Pointer() {
+			return ""
+		}
+		v := visit{got.Pointer(), want.Pointer(), got.Type()}
+		if d.visited[v] {
+			return ""
+		}
+		d.visited[v] = true
+	}
+
+	switch got.Kind() {
+	case reflect.Ptr:
+		return d.walk(path, got.Elem(), want.Elem())
+
+	case reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() == want.IsNil() {
+				return ""
+			}
+			return mismatch(describe(got), describe(want))
+		}
+		return d.walk(path, got.Elem(), want.Elem())
+
+	case reflect.Struct:
+		for i := 0; i < got.NumField(); i++ {
+			f := got.Type().Field(i)
+			p := path + "." + f.Name
+			if d.ignore[f.Name] || d.ignore[p[1:]] || (d.ignoreUnexported && f.PkgPath != "") {
+				continue
+			}
+			if s := d.walk(p, got.Field(i), want.Field(i)); s != "" {
+				if !d.all {
+					return s
+				}
+				d.found = append(d.found, s)
+			}
+		}
+		return ""
+
+	case reflect.Slice, reflect.Array:
+		n := got.Len()
+		if want.Len() < n {
+			n = want.Len()
+		}
+		for i := 0; i < n; i++ {
+			if s := d.walk(fmt.Sprintf("%s[%d]", path, i), got.Index(i), want.Index(i)); s != "" {
+				return s
+			}
+		}
+		if got.Len() != want.Len() {
+			return mismatch(sprintf("length %d", got.Len()), sprintf("length %d", want.Len()))
+		}
+		return ""
+
+	case reflect.Map:
+		keys := got.MapKeys()
+		for _, k := range want.MapKeys() {
+			if !got.MapIndex(k).IsValid() {
+				keys = append(keys, k)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keyLess(keys[i], keys[j]) })
+		for _, k := range keys {
+			g, w := got.MapIndex(k), want.MapIndex(k)
+			p := fmt.Sprintf("%s[%#v]", path, k)
+			switch {
+			case !g.IsValid():
+				return sprintf("%s: <missing> != %v", p, w)
+			case !w.IsValid():
+				return sprintf("%s: %v != <missing>", p, g)
+			}
+			if s := d.walk(p, g, w); s != "" {
+				return s
+			}
+		}
+		return ""
+
+	case reflect.Func:
+		if got.IsNil() && want.IsNil() {
+			return ""
+		}
+		return mismatch(describe(got), describe(want))
+
+	case reflect.Bool:
+		if got.Bool() == want.Bool() {
+			return ""
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if got.Int() == want.Int() {
+			return ""
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if got.Uint() == want.Uint() {
+			return ""
+		}
+	case reflect.Float32, reflect.Float64:
+		if got.Float() == want.Float() {
+			return ""
+		}
+	case reflect.Complex64, reflect.Complex128:
+		if got.Complex() == want.Complex() {
+			return ""
+		}
+	case reflect.String:
+		if got.String() == want.String() {
+			return ""
+		}
+		return mismatch(fmt.Sprintf("%q", got), fmt.Sprintf("%q", want))
+	case reflect.Chan, reflect.UnsafePointer:
+		if got.Pointer() == want.Pointer() {
+			return ""
+		}
+	}
+
+	return mismatch(got, want)
+}
+
+// Option configures the deep comparison made by [DeepEqual], [StructEqual] and [PreservesState].
+type Option func(*differ)
+
+// newDiffer returns a differ configured by opts.
+func newDiffer(opts []Option) *differ {
+	d := &differ{ignore: map[string]bool{}, comparers: map[reflect.Type]func(got, want reflect.Value) bool{}}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// IgnoreFields makes [DeepEqual], [StructEqual] and [PreservesState] skip the named fields. A plain name, e.g. "CreatedAt", matches fields of that name at any depth; a dotted path, e.g. "Owner.ID", matches only the field at that path from the root.
+func IgnoreFields(names ...string) Option {
+	return func(d *differ) {
+		for _, name := range names {
+			d.ignore[name] = true
+		}
+	}
+}
+
+// IgnoreUnexported makes [DeepEqual], [StructEqual] and [PreservesState] skip unexported struct fields at any depth.
+func IgnoreUnexported() Option {
+	return func(d *differ) { d.ignoreUnexported = true }
+}
+
+// EquivalentEmpty makes [DeepEqual], [StructEqual] and [PreservesState] treat nil and empty slices and maps as equal at any depth, e.g. a nil []string and []string{}.
+func EquivalentEmpty() Option {
+	return func(d *differ) { d.emptyEqual = true }
+}
+
+// Comparer makes the comparison compare values of type T with equal instead of structurally, e.g. Comparer(time.Time.Equal), so that types such as times or decimals are equal by what they represent. It is not applied to values held in unexported fields.
+func Comparer[T any](equal func(got, want T) bool) Option {
+	return func(d *differ) {
+		d.comparers[reflect.TypeOf((*T)(nil)).Elem()] = func(got, want reflect.Value) bool {
+			return equal(got.Interface().(T), want.Interface().(T))
+		}
+	}
+}
+
+// StructEqual returns a [Predicate] that is ok when got and want are deeply equal as for [DeepEqual], after applying opts: fields can be skipped with [IgnoreFields] and [IgnoreUnexported], and types compared with a custom function with [Comparer]. On failure every differing field is listed with its path, e.g. ".Owner.Name: "bob" != "alice"".
+func StructEqual(got, want any, opts ...Option) Predicate {
+	d := newDiffer(opts)
+	d.all = true
+
+	var (
+		once sync.Once
+		diff []string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			if s := d.diff(reflect.ValueOf(got), reflect.ValueOf(want)); s != "" {
+				d.found = append(d.found, s)
+			}
+			diff = d.found
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return len(diff) == 0 },
+		msg: func() string {
+			eval()
+			return sprintf("expected structs to be equal:\n  %s", strings.Join(diff, "\n  "))
+		},
+		desc:   func() string { return "structs equal" },
+		values: func() (any, any) { return got, want },
+	}
+}
+
+// describe renders v for a failure message, spelling out nil and invalid values.
+func describe(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return fmt.Sprintf("(%v)(nil)", v.Type())
+		}
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// PreservesState returns a [Predicate] that is ok when the state returned by capture is deeply equal (see [DeepEqual]), as adjusted by opts, before and after running f. On failure the path to the first changed value is reported.
+func PreservesState[S any](capture func() S, f func(), opts ...Option) Predicate {
+	d := newDiffer(opts)
+
+	var (
+		once sync.Once
+		diff string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			before := capture()
+			f()
+			after := capture()
+			diff = d.diff(reflect.ValueOf(after), reflect.ValueOf(before))
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return diff == "" },
+		msg: func() string {
+			eval()
+			return sprintf("expected state to be preserved (after != before)\n%s", diff)
+		},
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+	"time"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+type config struct {
+	Name    string
+	Timeout time.Duration
+	Tags    []string
+	Limits  map[string]int
+	Next    *config
+}
+
+type service struct {
+	Config config
+}
+
+func TestDeepEqual(t *testing.T) {
+	a := service{Config: config{Name: "a", Timeout: 5 * time.Second, Tags: []string{"x"}, Limits: map[string]int{"cpu": 1}}}
+	b := a
+
+	testspy.ExpectPass(t, observable.DeepEqual(a, b))
+	testspy.ExpectPass(t, observable.DeepEqual(nil, nil))
+	testspy.ExpectFail(t, observable.DeepEqual(1, int64(1)))
+	testspy.ExpectFail(t, observable.DeepEqual([]int(nil), []int{}))
+
+	cases := []struct {
+		mutate func(*service)
+		want   string
+	}{
+		{func(s *service) { s.Config.Timeout = 10 * time.Second }, ".Config.Timeout: 10s != 5s"},
+		{func(s *service) { s.Config.Tags = []string{"y"} }, `.Config.Tags[0]: "y" != "x"`},
+		{func(s *service) { s.Config.Tags = []string{"x", "y"} }, ".Config.Tags: length 2 != length 1"},
+		{func(s *service) { s.Config.Limits = map[string]int{"cpu": 2} }, `.Config.Limits["cpu"]: 2 != 1`},
+		{func(s *service) { s.Config.Limits = map[string]int{} }, `.Config.Limits["cpu"]: <missing> != 1`},
+		{func(s *service) { s.Config.Next = &config{} }, ".Config.Next: &{ 0s [] map[] <nil>} != (*observable_test.config)(nil)"},
+	}
+
+	for _, c := range cases {
+		got := a
+		c.mutate(&got)
+		testspy.ExpectFail(t, observable.DeepEqual(got, a))
+		testspy.ExpectPass(t, observable.ContainsSubstring(observable.DeepEqual(got, a).Message(), c.want))
+	}
+}
+
+func TestDeepEqualMapKeyOrder(t *testing.T) {
+	got := map[int]string{2: "b", 10: "j"}
+	want := map[int]string{2: "x", 10: "x"}
+
+	testspy.ExpectPass(t, observable.HasSuffix(observable.DeepEqual(got, want).Message(), "\n[2]: \"b\" != \"x\""))
+}
+
+func TestDeepEqualCycles(t *testing.T) {
+	a := &config{Name: "a"}
+	a.Next = a
+	b := &config{Name: "a"}
+	b.Next = b
+
+	testspy.ExpectPass(t, observable.DeepEqual(a, b))
+}
+
+func TestPreservesState(t *testing.T) {
+	cfg := config{Name: "a", Limits: map[string]int{"cpu": 1}}
+	capture := func() config {
+		c := cfg
+		c.Limits = map[string]int{}
+		for k, v := range cfg.Limits {
+			c.Limits[k] = v
+		}
+		return c
+	}
+
+	testspy.ExpectPass(t, observable.PreservesState(capture, func() { _ = cfg.Limits["cpu"] }))
+	testspy.ExpectFail(t, observable.PreservesState(capture, func() { cfg.Limits["cpu"] = 2 }))
+
+	msg := observable.PreservesState(capture, func() { cfg.Name = "b" }).Message()
+	testspy.ExpectPass(t, observable.ContainsSubstring(msg, `.Name: "b" != "a"`))
+}
+
+func TestDeepEqualComparer(t *testing.T) {
+	type event struct{ At time.Time }
+
+	utc := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	local := utc.In(time.FixedZone("UTC+1", 3600))
+	sameInstant := observable.Comparer(time.Time.Equal)
+
+	testspy.ExpectFail(t, observable.DeepEqual(event{local}, event{utc}))
+	testspy.ExpectPass(t, observable.DeepEqual(event{local}, event{utc}, sameInstant))
+	testspy.ExpectFail(t, observable.DeepEqual(event{local}, event{utc.Add(time.Second)}, sameInstant))
+
+	state := event{utc}
+	testspy.ExpectPass(t, observable.PreservesState(func() event { return state }, func() { state.At = state.At.In(local.Location()) }, sameInstant))
+	testspy.ExpectFail(t, observable.PreservesState(func() event { return state }, func() { state.At = state.At.Add(time.Second) }, sameInstant))
+}
+
+func TestStructEqual(t *testing.T) {
+	type owner struct {
+		ID   int
+		Name string
+	}
+	type record struct {
+		ID        int
+		Owner     owner
+		CreatedAt time.Time
+		note      string
+	}
+
+	now := time.Now()
+	got := record{ID: 1, Owner: owner{ID: 10, Name: "bob"}, CreatedAt: now, note: "x"}
+	want := record{ID: 2, Owner: owner{ID: 20, Name: "bob"}, CreatedAt: now.Add(time.Second), note: "y"}
+
+	testspy.ExpectPass(t, observable.StructEqual(got, got))
+	testspy.ExpectFail(t, observable.StructEqual(got, want))
+	testspy.ExpectPass(t, observable.StructEqual(got, want, observable.IgnoreFields("ID", "CreatedAt"), observable.IgnoreUnexported()))
+	testspy.ExpectFail(t, observable.StructEqual(got, want, observable.IgnoreFields("ID", "CreatedAt")))
+	testspy.ExpectFail(t, observable.StructEqual(got, want, observable.IgnoreFields("Owner.ID", "CreatedAt"), observable.IgnoreUnexported()))
+	testspy.ExpectPass(t, observable.StructEqual(&got, &want,
+		observable.IgnoreFields("ID"),
+		observable.IgnoreUnexported(),
+		observable.Comparer(func(a, b time.Time) bool { d := a.Sub(b); return -time.Minute <= d && d <= time.Minute }),
+	))
+
+	msg := observable.StructEqual(got, want, observable.IgnoreFields("CreatedAt")).Message()
+	wantMsg := "expected structs to be equal:\n  .ID: 1 != 2\n  .Owner.ID: 10 != 20\n  .note: \"x\" != \"y\""
+	if msg != wantMsg {
+		t.Errorf("got message\n%s\nwant\n%s", msg, wantMsg)
+	}
+}
+
+func TestStructEqualEquivalentEmpty(t *testing.T) {
+	type user struct {
+		Tags   []string
+		Labels map[string]string
+		Groups [][]int
+	}
+
+	got := user{Tags: nil, Labels: map[string]string{}, Groups: [][]int{nil}}
+	want := user{Tags: []string{}, Labels: nil, Groups: [][]int{{}}}
+
+	testspy.ExpectFail(t, observable.StructEqual(got, want))
+	testspy.ExpectPass(t, observable.StructEqual(got, want, observable.EquivalentEmpty()))
+	testspy.ExpectFail(t, observable.StructEqual(got, user{Tags: []string{"a"}}, observable.EquivalentEmpty()))
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// diffContext is the number of unchanged lines shown around each change in a line diff.
+const diffContext = 3
+
+// maxDiffCells bounds the size of the table used to compute line diffs. Inputs exceeding it are reported by their first differing line only.
+const maxDiffCells = 1 << 22
+
+// lineDiff renders a line-by-line diff from want to got. Removed lines are prefixed with "-" and the line number in want, added lines with "+" and the line number in got.
+func lineDiff(want, got string) string {
+	a := strings.Split(want, "\n")
+	b := strings.Split(got, "\n")
+
+	if len(a)*len(b) > maxDiffCells {
+		return firstLineDiff(a, b)
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	type edit struct {
+		op   byte
+		line int
+		text string
+	}
+
+	var edits []edit
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{' ', j + 1, b[j]})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			edits = append(edits, edit{'+', j + 1, b[j]})
+			j++
+		default:
+			edits = append(edits, edit{'-', i + 1, a[i]})
+			i++
+		}
+	}
+
+	// Only show unchanged lines that are within diffContext of a change.
+	show := make([]bool, len(edits))
+	for k, e := range edits {
+		if e.op == ' ' {
+			continue
+		}
+		for c := k - diffContext; c <= k+diffContext; c++ {
+			if c >= 0 && c < len(edits) {
+				show[c] = true
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("--- want\n+++ got\n")
+	skipped := false
+	for k, e := range edits {
+		if !show[k] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			sb.WriteString("  ...\n")
+			skipped = false
+		}
+		fmt.Fprintf(&sb, "%c%4d: %s\n", e.op, e.line, e.text)
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// firstLineDiff reports the first line at which a and b differ.
+func firstLineDiff(a, b []string) string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	line := func(s []string) string {
+		if i < len(s) {
+			return fmt.Sprintf("%q", s[i])
+		}
+		return "<end of input>"
+	}
+
+	return sprintf("first difference at line %d\nwant: %s\ngot:  %s", i+1, line(a), line(b))
+}
+
+// contentDiff describes how got differs from want: as a line diff when both are text, and by their first differing byte otherwise.
+func contentDiff(want, got []byte) string {
+	if isText(want) && isText(got) {
+		return lineDiff(string(want), string(got))
+	}
+
+	return byteDiff(want, got)
+}
+
+// isText reports whether b looks like text rather than binary data.
+func isText(b []byte) bool {
+	return utf8.Valid(b) && bytes.IndexByte(b, 0) < 0
+}
+
+// byteDiff reports the first offset at which want and got differ, with a hex dump of the surrounding bytes.
+func byteDiff(want, got []byte) string {
+	i := 0
+	for i < len(want) && i < len(got) && want[i] == got[i] {
+		i++
+	}
+
+	start := i - i%16
+	window := func(s []byte) string {
+		if start >= len(s) {
+			return "<end of input>"
+		}
+		end := start + 32
+		if end > len(s) {
+			end = len(s)
+		}
+		return hex.EncodeToString(s[start:end])
+	}
+
+	return sprintf("first difference at byte offset %d (want %d bytes, got %d bytes)\nwant[%d:]: %s\ngot[%d:]:  %s", i, len(want), len(got), start, window(want), start, window(got))
+}
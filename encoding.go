@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ValidUTF8 returns a [Predicate] that is ok when s is valid UTF-8. On failure the byte offset of the first invalid sequence is reported.
+func ValidUTF8(s string) Predicate {
+	return validEncoding("UTF-8", s, func(s string) (int, string) {
+		for i := 0; i < len(s); {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				return i, sprintf("invalid byte %#02x", s[i])
+			}
+			i += size
+		}
+		return -1, ""
+	})
+}
+
+// ValidBase64 returns a [Predicate] that is ok when s is padded standard base64 ([base64.StdEncoding]). On failure the byte offset of the first invalid character is reported.
+func ValidBase64(s string) Predicate {
+	return validEncoding("base64", s, func(s string) (int, string) {
+		_, err := base64.StdEncoding.DecodeString(s)
+		var corrupt base64.CorruptInputError
+		if errors.As(err, &corrupt) {
+			return int(corrupt), "illegal data"
+		}
+		return -1, ""
+	})
+}
+
+// ValidHex returns a [Predicate] that is ok when s is an even-length string of hexadecimal digits. On failure the byte offset of the first invalid character, or of the dangling final digit, is reported.
+func ValidHex(s string) Predicate {
+	return validEncoding("hex", s, func(s string) (int, string) {
+		for i := 0; i < len(s); i++ {
+			if !strings.ContainsRune("0123456789abcdefABCDEF", rune(s[i])) {
+				return i, sprintf("invalid character %q", s[i])
+			}
+		}
+		if len(s)%2 == 1 {
+			return len(s) - 1, "odd length"
+		}
+		return -1, ""
+	})
+}
+
+// ValidJSON returns a [Predicate] that is ok when s is a single well-formed JSON value. On failure the byte offset of the syntax error is reported.
+func ValidJSON(s string) Predicate {
+	return validEncoding("JSON", s, func(s string) (int, string) {
+		if json.Valid([]byte(s)) {
+			return -1, ""
+		}
+		var v any
+		err := json.Unmarshal([]byte(s), &v)
+		var syntax *json.SyntaxError
+		if errors.As(err, &syntax) {
+			// Offset counts the bytes read, including the offending character.
+			offset := int(syntax.Offset)
+			if strings.HasPrefix(syntax.Error(), "invalid character") {
+				offset--
+			}
+			return offset, syntax.Error()
+		}
+		return len(s), "unexpected end of input"
+	})
+}
+
+// validEncoding builds a predicate from check, which returns the offset of the first error in s and a description of it, or -1 if s is valid.
+func validEncoding(name, s string, check func(s string) (offset int, problem string)) Predicate {
+	var (
+		once    sync.Once
+		offset  int
+		problem string
+	)
+
+	eval := func() { once.Do(func() { offset, problem = check(s) }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return offset < 0 },
+		msg: func() string {
+			eval()
+			return sprintf("expected valid %s, %s at byte offset %d: %s", name, problem, offset, excerpt(s, offset))
+		},
+		neg:  func() string { return sprintf("expected invalid %s, got %q", name, s) },
+		desc: func() string { return "valid " + name },
+	}
+}
+
+// excerptRadius is the number of bytes shown on either side of an offset by [excerpt].
+const excerptRadius = 16
+
+// excerpt quotes the part of s around offset, marking the offset with "▶".
+func excerpt(s string, offset int) string {
+	start, end := offset-excerptRadius, offset+excerptRadius
+	if start < 0 {
+		start = 0
+	}
+	if end > len(s) {
+		end = len(s)
+	}
+	if offset > len(s) {
+		offset = len(s)
+	}
+
+	prefix, suffix := "", ""
+	if start > 0 {
+		prefix = "…"
+	}
+	if end < len(s) {
+		suffix = "…"
+	}
+
+	quote := func(s string) string { q := strconv.Quote(s); return q[1 : len(q)-1] }
+
+	return prefix + quote(s[start:offset]) + "▶" + quote(s[offset:end]) + suffix
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestEncodingChecks(t *testing.T) {
+	testspy.ExpectPass(t, observable.ValidUTF8("héllo"))
+	testspy.ExpectFail(t, observable.ValidUTF8("h\xffllo"))
+
+	testspy.ExpectPass(t, observable.ValidBase64("aGVsbG8="))
+	testspy.ExpectFail(t, observable.ValidBase64("aGVs*G8="))
+	testspy.ExpectFail(t, observable.ValidBase64("aGVsbG8"))
+
+	testspy.ExpectPass(t, observable.ValidHex("deadBEEF"))
+	testspy.ExpectFail(t, observable.ValidHex("dead-beef"))
+	testspy.ExpectFail(t, observable.ValidHex("abc"))
+
+	testspy.ExpectPass(t, observable.ValidJSON(`{"a": [1, 2, null]}`))
+	testspy.ExpectFail(t, observable.ValidJSON(`{"a": [1, 2,]}`))
+	testspy.ExpectFail(t, observable.ValidJSON(`{"a": 1`))
+	testspy.ExpectFail(t, observable.ValidJSON(`1 2`))
+
+	for _, tc := range []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.ValidUTF8("h\xffllo"), `expected valid UTF-8, invalid byte 0xff at byte offset 1: h▶\xffllo`},
+		{observable.ValidBase64("aGVs*G8="), `expected valid base64, illegal data at byte offset 4: aGVs▶*G8=`},
+		{observable.ValidHex("abc"), `expected valid hex, odd length at byte offset 2: ab▶c`},
+		{observable.ValidJSON(`{"a": [1, 2,]}`), `expected valid JSON, invalid character ']' looking for beginning of value at byte offset 12: {\"a\": [1, 2,▶]}`},
+	} {
+		if msg := tc.p.Message(); msg != tc.want {
+			t.Errorf("got message %q, want %q", msg, tc.want)
+		}
+	}
+}
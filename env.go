@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// EnvSet returns a [Predicate] that is ok when the environment variable key is set, possibly to the empty string.
+//
+// The environment is read at most once, on first evaluation, not when the predicate is built.
+func EnvSet(key string) Predicate {
+	lookup := lookupEnv(key)
+
+	return Predicate{
+		ok:  func() bool { _, set := lookup(); return set },
+		msg: func() string { return sprintf("expected environment variable %s to be set", key) },
+		neg: func() string {
+			v, _ := lookup()
+			return sprintf("expected environment variable %s to be unset, got %q", key, v)
+		},
+		desc: func() string { return fmt.Sprintf("%s set", key) },
+	}
+}
+
+// EnvUnset returns a [Predicate] that is ok when the environment variable key is not set. On failure its value is reported.
+//
+// The environment is read at most once, on first evaluation, not when the predicate is built.
+func EnvUnset(key string) Predicate {
+	lookup := lookupEnv(key)
+
+	return Predicate{
+		ok: func() bool { _, set := lookup(); return !set },
+		msg: func() string {
+			v, _ := lookup()
+			return sprintf("expected environment variable %s to be unset, got %q", key, v)
+		},
+		neg:  func() string { return sprintf("expected environment variable %s to be set", key) },
+		desc: func() string { return fmt.Sprintf("%s unset", key) },
+	}
+}
+
+// EnvEqual returns a [Predicate] that is ok when the environment variable key is set to want. On failure its value is reported, or that it is unset.
+//
+// The environment is read at most once, on first evaluation, not when the predicate is built.
+func EnvEqual(key, want string) Predicate {
+	lookup := lookupEnv(key)
+
+	return Predicate{
+		ok: func() bool { v, set := lookup(); return set && v == want },
+		msg: func() string {
+			v, set := lookup()
+			if !set {
+				return sprintf("expected environment variable %s=%q, it is unset", key, want)
+			}
+			return sprintf("expected environment variable %s=%q, got %q", key, want, v)
+		},
+		neg:  func() string { return sprintf("expected environment variable %s not to be %q", key, want) },
+		desc: func() string { return fmt.Sprintf("%s == %q", key, want) },
+	}
+}
+
+// lookupEnv returns a function that looks up key with [os.LookupEnv] on its first call and returns that result on every call.
+func lookupEnv(key string) func() (string, bool) {
+	var (
+		once  sync.Once
+		value string
+		set   bool
+	)
+
+	return func() (string, bool) {
+		once.Do(func() { value, set = os.LookupEnv(key) })
+		return value, set
+	}
+}
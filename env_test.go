@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"os"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestEnv(t *testing.T) {
+	t.Setenv("OBSERVABLE_TEST_SET", "on")
+	t.Setenv("OBSERVABLE_TEST_EMPTY", "")
+	os.Unsetenv("OBSERVABLE_TEST_UNSET")
+
+	testspy.ExpectPass(t, observable.EnvSet("OBSERVABLE_TEST_SET"))
+	testspy.ExpectPass(t, observable.EnvSet("OBSERVABLE_TEST_EMPTY"))
+	testspy.ExpectFail(t, observable.EnvSet("OBSERVABLE_TEST_UNSET"))
+	testspy.ExpectPass(t, observable.EnvUnset("OBSERVABLE_TEST_UNSET"))
+	testspy.ExpectFail(t, observable.EnvUnset("OBSERVABLE_TEST_SET"))
+	testspy.ExpectPass(t, observable.EnvEqual("OBSERVABLE_TEST_SET", "on"))
+	testspy.ExpectFail(t, observable.EnvEqual("OBSERVABLE_TEST_SET", "off"))
+	testspy.ExpectFail(t, observable.EnvEqual("OBSERVABLE_TEST_UNSET", ""))
+	testspy.ExpectFail(t, observable.Not(observable.EnvSet("OBSERVABLE_TEST_SET")))
+
+	testspy.ExpectPass(t, observable.ContainsSubstring(observable.EnvUnset("OBSERVABLE_TEST_SET").Message(), `got "on"`))
+	testspy.ExpectPass(t, observable.ContainsSubstring(observable.EnvEqual("OBSERVABLE_TEST_SET", "off").Message(), `OBSERVABLE_TEST_SET="off", got "on"`))
+	testspy.ExpectPass(t, observable.ContainsSubstring(observable.EnvEqual("OBSERVABLE_TEST_UNSET", "x").Message(), "it is unset"))
+}
+
+func TestEnvLazy(t *testing.T) {
+	p := observable.EnvEqual("OBSERVABLE_TEST_LAZY", "late")
+	t.Setenv("OBSERVABLE_TEST_LAZY", "late")
+
+	testspy.ExpectPass(t, p)
+}
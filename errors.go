@@ -5,38 +5,47 @@ package observable
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
 )
 
 // ErrorIs returns a [Predicate] that is ok when [errors.Is](err, target) is true.
 func ErrorIs(err, target error) Predicate {
 	return Predicate{
-		ok: func() bool { return errors.Is(err, target) },
+		ok: memo(func() bool { return errors.Is(err, target) }),
 		msg: func() string {
-			return fmt.Sprintf("expected error %v to match %v", err, target)
+			return sprintf("expected error %v to match %v", err, target)
 		},
+		neg:  func() string { return sprintf("expected error %v not to match %v", err, target) },
+		desc: func() string { return fmt.Sprintf("err is %v", target) },
 	}
 }
 
 // Errors returns a [Predicate] that is ok when f returns a non‑nil error.
 func Errors(f func() error) Predicate {
 	return Predicate{
-		ok:  func() bool { return f() != nil },
-		msg: func() string { return "expected function to return a non-nil error" },
+		ok:   memo(func() bool { return f() != nil }),
+		msg:  func() string { return sprintf("expected function to return a non-nil error") },
+		desc: func() string { return "returns error" },
 	}
 }
 
 // ErrorsWith returns a [Predicate] that is ok when f returns an error that matches target according to [errors.Is].
 func ErrorsWith(f func() error, target error) Predicate {
 	return Predicate{
-		ok:  func() bool { return errors.Is(f(), target) },
-		msg: func() string { return fmt.Sprintf("expected returned error to match %v", target) },
+		ok:   memo(func() bool { return errors.Is(f(), target) }),
+		msg:  func() string { return sprintf("expected returned error to match %v", target) },
+		desc: func() string { return fmt.Sprintf("returns error %v", target) },
 	}
 }
 
 // Panics returns a [Predicate] that is ok when f panics.
 func Panics(f func()) Predicate {
 	return Predicate{
-		ok: func() (panicked bool) {
+		ok: memo(func() (panicked bool) {
 			defer func() {
 				if recover() != nil {
 					panicked = true
@@ -44,7 +53,217 @@ func Panics(f func()) Predicate {
 			}()
 			f()
 			return
+		}),
+		msg:  func() string { return sprintf("expected function to panic") },
+		neg:  func() string { return sprintf("expected function not to panic") },
+		desc: func() string { return "panics" },
+	}
+}
+
+// ErrorContains returns a [Predicate] that is ok when err is non-nil and its message contains substr. On failure the full error chain is reported.
+func ErrorContains(err error, substr string) Predicate {
+	return Predicate{
+		ok: memo(func() bool { return err != nil && strings.Contains(err.Error(), substr) }),
+		msg: func() string {
+			if err == nil {
+				return sprintf("expected error containing %q, got nil", substr)
+			}
+			return sprintf("expected error containing %q, got %q\n%s", substr, err.Error(), errorChain(err))
+		},
+		desc: func() string { return fmt.Sprintf("error contains %q", substr) },
+	}
+}
+
+// ErrorMatches returns a [Predicate] that is ok when err is non-nil and its message matches the regular expression pattern. The pattern is compiled when the predicate is built; a malformed pattern makes the predicate fail with the compile error, and the negation of such a predicate fails too. On failure the full error chain is reported.
+func ErrorMatches(err error, pattern string) Predicate {
+	re, compileErr := regexp.Compile(pattern)
+
+	return Predicate{
+		ok:  memo(func() bool { return compileErr == nil && err != nil && re.MatchString(err.Error()) }),
+		err: func() error { return compileErr },
+		msg: func() string {
+			switch {
+			case compileErr != nil:
+				return sprintf("expected error matching %q: invalid pattern: %v", pattern, compileErr)
+			case err == nil:
+				return sprintf("expected error matching %q, got nil", pattern)
+			}
+			return sprintf("expected error matching %q, got %q\n%s", pattern, err.Error(), errorChain(err))
+		},
+		desc: func() string { return fmt.Sprintf("error matches %q", pattern) },
+	}
+}
+
+// OK asserts that err is nil and reports whether it is. On failure err is rendered with %+v, so errors that record stack traces print them, followed by the full error chain when err wraps other errors. When err is nil no predicate or message is built.
+func OK(tb testing.TB, err error) bool {
+	if nilTB(tb) {
+		return err == nil || Assert(nil, noError(err))
+	}
+
+	tb.Helper()
+
+	if err == nil {
+		return observe(tb, "", true, "", nil)
+	}
+
+	return Assert(tb, noError(err))
+}
+
+// noError returns the failing predicate [OK] reports for the non-nil err.
+func noError(err error) Predicate {
+	return Predicate{
+		ok: func() bool { return false },
+		msg: func() string {
+			if isRoot(err) {
+				return sprintf("expected no error, got: %+v", err)
+			}
+			return sprintf("expected no error, got: %+v\n%s", err, errorChain(err))
+		},
+	}
+}
+
+// MustOK behaves like [OK] but stops the test with FailNow when err is not nil.
+func MustOK(tb testing.TB, err error) {
+	if nilTB(tb) {
+		OK(nil, err)
+		return
+	}
+
+	tb.Helper()
+
+	if !OK(tb, err) {
+		tb.FailNow()
+	}
+}
+
+// ChainContains returns a [Predicate] that is ok when target is err itself or any error reachable from it through Unwrap, compared with ==. Unlike [ErrorIs], Is methods are not consulted. On failure the full error chain is reported.
+func ChainContains(err, target error) Predicate {
+	return Predicate{
+		ok: memo(func() bool {
+			found := false
+			walkChain(err, func(e error, _ int) {
+				if !found && isError(e, target) {
+					found = true
+				}
+			})
+			return found
+		}),
+		msg: func() string {
+			return sprintf("expected error chain to contain %v\n%s", target, errorChain(err))
+		},
+		neg: func() string {
+			return sprintf("expected error chain not to contain %v\n%s", target, errorChain(err))
 		},
-		msg: func() string { return "expected function to panic" },
+		desc: func() string { return fmt.Sprintf("error chain contains %v", target) },
+	}
+}
+
+// ChainDepth returns a [Predicate] that is ok when the longest chain of errors reachable from err through Unwrap, counting err itself, has exactly n errors. A nil error has depth 0. On failure the full error chain is reported.
+func ChainDepth(err error, n int) Predicate {
+	var (
+		once  sync.Once
+		depth int
+	)
+
+	eval := func() {
+		once.Do(func() {
+			walkChain(err, func(_ error, d int) {
+				if d+1 > depth {
+					depth = d + 1
+				}
+			})
+		})
 	}
+
+	return Predicate{
+		ok: func() bool { eval(); return depth == n },
+		msg: func() string {
+			eval()
+			return sprintf("expected error chain of depth %d, got %d\n%s", n, depth, errorChain(err))
+		},
+		desc: func() string { return fmt.Sprintf("error chain depth %d", n) },
+	}
+}
+
+// RootCause returns a [Predicate] that is ok when target is a root cause of err: an error in its chain that wraps nothing further, compared with ==. Errors joined with errors.Join have several root causes; any of them may match. On failure the full error chain is reported.
+func RootCause(err, target error) Predicate {
+	return Predicate{
+		ok: memo(func() bool {
+			found := false
+			walkChain(err, func(e error, _ int) {
+				if !found && isRoot(e) && isError(e, target) {
+					found = true
+				}
+			})
+			return found
+		}),
+		msg: func() string {
+			return sprintf("expected root cause %v\n%s", target, errorChain(err))
+		},
+		neg: func() string {
+			return sprintf("expected root cause other than %v\n%s", target, errorChain(err))
+		},
+		desc: func() string { return fmt.Sprintf("root cause %v", target) },
+	}
+}
+
+// isError reports whether err and target are the same error, without panicking on incomparable error types.
+func isError(err, target error) bool {
+	t := reflect.TypeOf(target)
+	if t == nil || reflect.TypeOf(err) != t || !t.Comparable() {
+		return false
+	}
+	return err == target
+}
+
+// isRoot reports whether err wraps no further errors.
+func isRoot(err error) bool {
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return x.Unwrap() == nil
+	case interface{ Unwrap() []error }:
+		for _, next := range x.Unwrap() {
+			if next != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// walkChain calls f for err and every error reachable from it through Unwrap, in depth-first order, with each error's depth below err. Errors that wrap several errors (e.g. created by errors.Join) have each branch visited.
+func walkChain(err error, f func(err error, depth int)) {
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		f(err, depth)
+
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			if next := x.Unwrap(); next != nil {
+				walk(next, depth+1)
+			}
+		case interface{ Unwrap() []error }:
+			for _, next := range x.Unwrap() {
+				if next != nil {
+					walk(next, depth+1)
+				}
+			}
+		}
+	}
+
+	if err != nil {
+		walk(err, 0)
+	}
+}
+
+// errorChain renders err and every error reachable from it through Unwrap, one per line and indented by depth. Errors that wrap several errors (e.g. created by errors.Join) have each branch listed.
+func errorChain(err error) string {
+	var sb strings.Builder
+	sb.WriteString("error chain:")
+
+	walkChain(err, func(err error, depth int) {
+		fmt.Fprintf(&sb, "\n%s%T: %v", strings.Repeat("  ", depth+1), err, err)
+	})
+
+	return sb.String()
 }
@@ -3,6 +3,9 @@
 package observable_test
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"renorm.dev/observable"
@@ -40,3 +43,75 @@ func TestPanicsChecks(t *testing.T) {
 	testspy.ExpectPass(t, observable.Not(observable.Panics)(func() {}))
 	testspy.ExpectFail(t, observable.Not(observable.Panics)(func() { panic("boom") }))
 }
+
+func TestErrorContainsChecks(t *testing.T) {
+	wrapped := fmt.Errorf("opening config: %w", errFoo)
+
+	testspy.ExpectPass(t, observable.ErrorContains(wrapped, "config"))
+	testspy.ExpectFail(t, observable.ErrorContains(wrapped, "database"))
+	testspy.ExpectFail(t, observable.ErrorContains(nil, "config"))
+
+	testspy.ExpectPass(t, observable.ErrorMatches(wrapped, `^opening \w+: foo$`))
+	testspy.ExpectFail(t, observable.ErrorMatches(wrapped, `^closing`))
+	testspy.ExpectFail(t, observable.ErrorMatches(nil, `.*`))
+
+	bad := observable.ErrorMatches(wrapped, `(`)
+	testspy.ExpectFail(t, bad)
+	testspy.ExpectFail(t, observable.Not(bad))
+	testspy.ExpectPass(t, observable.HasPrefix(bad.Message(), "expected error matching \"(\": invalid pattern: error parsing regexp"))
+
+	msg := observable.ErrorContains(wrapped, "database").Message()
+	if !strings.Contains(msg, "*fmt.wrapError: opening config: foo") || !strings.Contains(msg, "*errors.errorString: foo") {
+		t.Errorf("expected message to contain the error chain, got %q", msg)
+	}
+}
+
+// joined wraps several errors, like the result of errors.Join.
+type joined []error
+
+func (j joined) Error() string   { return "joined" }
+func (j joined) Unwrap() []error { return j }
+
+func TestErrorChainChecks(t *testing.T) {
+	errBar := errors.New("bar")
+	wrapped := fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", errFoo))
+	multi := fmt.Errorf("outer: %w", joined{errBar, fmt.Errorf("inner: %w", errFoo)})
+
+	testspy.ExpectPass(t, observable.ChainContains(wrapped, errFoo))
+	testspy.ExpectPass(t, observable.ChainContains(multi, errBar))
+	testspy.ExpectFail(t, observable.ChainContains(wrapped, errBar))
+	testspy.ExpectFail(t, observable.ChainContains(nil, errFoo))
+
+	testspy.ExpectPass(t, observable.ChainDepth(wrapped, 3))
+	testspy.ExpectPass(t, observable.ChainDepth(multi, 4))
+	testspy.ExpectPass(t, observable.ChainDepth(nil, 0))
+	testspy.ExpectFail(t, observable.ChainDepth(errFoo, 2))
+
+	testspy.ExpectPass(t, observable.RootCause(wrapped, errFoo))
+	testspy.ExpectPass(t, observable.RootCause(multi, errBar))
+	testspy.ExpectPass(t, observable.RootCause(multi, errFoo))
+	testspy.ExpectFail(t, observable.RootCause(wrapped, wrapped))
+
+	msg := observable.ChainDepth(wrapped, 2).Message()
+	if !strings.Contains(msg, "expected error chain of depth 2, got 3") || !strings.Contains(msg, "*errors.errorString: foo") {
+		t.Errorf("unexpected message: %q", msg)
+	}
+}
+
+func TestOK(t *testing.T) {
+	spy := testspy.New(t)
+
+	if !observable.OK(spy, nil) || len(spy.Messages) != 0 {
+		t.Fatal("expected OK(nil) to pass")
+	}
+
+	if observable.OK(spy, fmt.Errorf("loading: %w", errFoo)) {
+		t.Fatal("expected OK(err) to fail")
+	}
+	if msg := spy.Messages[0]; !strings.HasPrefix(msg, "expected no error, got: loading: foo\nerror chain:") {
+		t.Errorf("unexpected message: %q", msg)
+	}
+
+	observable.MustOK(spy, nil)
+	testspy.ExpectPass(t, observable.Panics(func() { observable.MustOK(spy, errFoo) }))
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Event describes a single assertion evaluated by [Assert], [Assertf] or another asserting helper in this package.
+type Event struct {
+	// Test is the name of the test the assertion ran in.
+	Test string
+	// ID is the predicate's ID (see [Predicate.WithID]), or "".
+	ID string
+	// File and Line locate the assertion in the calling code.
+	File string
+	Line int
+	// Passed reports whether the assertion held.
+	Passed bool
+	// Message is the failure message, or "" when the assertion passed.
+	Message string
+	// Annotations holds the metadata attached to the test and its parents with [Annotate]; a later value for a key replaces an earlier one.
+	Annotations map[string]string
+}
+
+type observer struct{ fn func(Event) }
+
+var (
+	observersMu sync.RWMutex
+	observers   []*observer
+)
+
+// Observe registers fn to be called with an [Event] for every assertion evaluated by this package, in any test. It returns a function that unregisters fn.
+//
+// fn may be called concurrently from parallel tests.
+func Observe(fn func(Event)) (remove func()) {
+	o := &observer{fn: fn}
+
+	observersMu.Lock()
+	observers = append(observers, o)
+	observersMu.Unlock()
+
+	return func() {
+		observersMu.Lock()
+		defer observersMu.Unlock()
+
+		for i, x := range observers {
+			if x == o {
+				observers = append(observers[:i:i], observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// emit notifies all registered observers of an assertion. The caller's location is only resolved when there are observers.
+func emit(tb testing.TB, id string, ok bool, message string) {
+	observersMu.RLock()
+	obs := append([]*observer(nil), observers...)
+	observersMu.RUnlock()
+
+	if len(obs) == 0 {
+		return
+	}
+
+	e := Event{Test: tb.Name(), ID: id, Passed: ok}
+	e.File, e.Line = callerLocation()
+	if !ok {
+		e.Message = message
+	}
+	if kv := annotationsOf(tb); len(kv) > 0 {
+		e.Annotations = make(map[string]string, len(kv)/2)
+		for i := 0; i < len(kv); i += 2 {
+			e.Annotations[kv[i]] = kv[i+1]
+		}
+	}
+
+	for _, o := range obs {
+		o.fn(e)
+	}
+}
+
+// callerLocation returns the file and line of the innermost stack frame outside this package.
+func callerLocation() (string, int) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "renorm.dev/observable.") {
+			return frame.File, frame.Line
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
+
+// Recorder collects the [Event]s of every assertion evaluated while it is recording. It is intended for tools such as mutation-testing drivers that need assertion-level signals.
+type Recorder struct {
+	mu     sync.Mutex
+	events []Event
+	stop   func()
+}
+
+// Record returns a [Recorder] that records events until [Recorder.Stop] is called.
+func Record() *Recorder {
+	r := &Recorder{}
+	r.stop = Observe(func(e Event) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.events = append(r.events, e)
+	})
+
+	return r
+}
+
+// Stop stops recording. Events recorded so far remain available.
+func (r *Recorder) Stop() { r.stop() }
+
+// Events returns a copy of the recorded events in the order they occurred.
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Event(nil), r.events...)
+}
+
+// eventsMagic identifies the binary event export format.
+const eventsMagic = "OBSEV1"
+
+// maxEventString bounds the length of strings decoded by [ReadEvents] so corrupt input cannot cause huge allocations.
+const maxEventString = 1 << 20
+
+// WriteBinary writes the recorded events to w in a compact binary form that can be read back with [ReadEvents]. Failure messages are omitted.
+func (r *Recorder) WriteBinary(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(eventsMagic)
+
+	var buf [binary.MaxVarintLen64]byte
+	putUvarint := func(x uint64) { bw.Write(buf[:binary.PutUvarint(buf[:], x)]) }
+	putString := func(s string) { putUvarint(uint64(len(s))); bw.WriteString(s) }
+
+	for _, e := range r.Events() {
+		putString(e.Test)
+		putString(e.ID)
+		putString(e.File)
+		putUvarint(uint64(e.Line))
+		if e.Passed {
+			bw.WriteByte(1)
+		} else {
+			bw.WriteByte(0)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ReadEvents decodes events written by [Recorder.WriteBinary].
+func ReadEvents(r io.Reader) ([]Event, error) {
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(eventsMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != eventsMagic {
+		return nil, errors.New("not an observable event stream")
+	}
+
+	readString := func() (string, error) {
+		n, err := binary.ReadUvarint(br)
+		if err != nil {
+			return "", err
+		}
+		if n > maxEventString {
+			return "", fmt.Errorf("string length %d exceeds limit", n)
+		}
+		b := make([]byte, n)
+		_, err = io.ReadFull(br, b)
+		return string(b), err
+	}
+
+	var events []Event
+	for {
+		if _, err := br.Peek(1); err == io.EOF {
+			return events, nil
+		}
+
+		var (
+			e   Event
+			err error
+		)
+		if e.Test, err = readString(); err != nil {
+			return events, fmt.Errorf("reading event %d: %w", len(events), err)
+		}
+		if e.ID, err = readString(); err != nil {
+			return events, fmt.Errorf("reading event %d: %w", len(events), err)
+		}
+		if e.File, err = readString(); err != nil {
+			return events, fmt.Errorf("reading event %d: %w", len(events), err)
+		}
+		line, err := binary.ReadUvarint(br)
+		if err != nil {
+			return events, fmt.Errorf("reading event %d: %w", len(events), err)
+		}
+		e.Line = int(line)
+		passed, err := br.ReadByte()
+		if err != nil {
+			return events, fmt.Errorf("reading event %d: %w", len(events), err)
+		}
+		e.Passed = passed == 1
+
+		events = append(events, e)
+	}
+}
+
+// CountOption configures [CountAssertions].
+type CountOption func(*countConfig)
+
+type countConfig struct {
+	require bool
+}
+
+// RequireAssertions makes [CountAssertions] fail the test at Cleanup if no assertion was evaluated in it, catching tests that silently stopped asserting, e.g. because a refactor left a table empty.
+func RequireAssertions() CountOption {
+	return func(c *countConfig) { c.require = true }
+}
+
+// CountAssertions starts counting the assertions evaluated in tb and its subtests, as reported to [Observe], and returns a function reporting the count so far. Counting stops when tb's test ends.
+func CountAssertions(tb testing.TB, opts ...CountOption) (count func() int) {
+	tb.Helper()
+
+	var cfg countConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		mu sync.Mutex
+		n  int
+	)
+
+	name := tb.Name()
+	remove := Observe(func(e Event) {
+		if e.Test == name || strings.HasPrefix(e.Test, name+"/") {
+			mu.Lock()
+			n++
+			mu.Unlock()
+		}
+	})
+
+	count = func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return n
+	}
+
+	tb.Cleanup(func() {
+		remove()
+		if cfg.require && count() == 0 {
+			tb.Error("expected at least one assertion, none ran")
+		}
+	})
+
+	return count
+}
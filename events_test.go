@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestRecorder(t *testing.T) {
+	rec := observable.Record()
+	observable.Assert(t, observable.True().WithID("A-1"))
+	observable.Assert(testspy.New(t), observable.False())
+	rec.Stop()
+	observable.Assert(t, observable.True())
+
+	events := rec.Events()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	if e := events[0]; !e.Passed || e.ID != "A-1" || e.Test != t.Name() || !strings.HasSuffix(e.File, "events_test.go") {
+		t.Errorf("unexpected first event %+v", e)
+	}
+	if e := events[1]; e.Passed || e.Message != "false" {
+		t.Errorf("unexpected second event %+v", e)
+	}
+
+	var buf bytes.Buffer
+	if err := rec.WriteBinary(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := observable.ReadEvents(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 2 || decoded[0].Line != events[0].Line || decoded[1].Passed {
+		t.Errorf("expected events to round-trip, got %+v", decoded)
+	}
+
+	if _, err := observable.ReadEvents(strings.NewReader("junk")); err == nil {
+		t.Error("expected error decoding invalid stream")
+	}
+}
+
+func TestCountAssertions(t *testing.T) {
+	count := observable.CountAssertions(t)
+	observable.Assert(t, observable.True())
+	t.Run("sub", func(t *testing.T) {
+		observable.Assert(t, observable.True())
+	})
+	observable.Assert(testspy.New(t), observable.False())
+	if n := count(); n != 3 {
+		t.Errorf("got %d assertions, want 3", n)
+	}
+
+	var idle, busy *testspy.SpyTB
+	t.Run("idle", func(t *testing.T) {
+		idle = testspy.New(t)
+		observable.CountAssertions(idle, observable.RequireAssertions())
+	})
+	t.Run("busy", func(t *testing.T) {
+		busy = testspy.New(t)
+		observable.CountAssertions(busy, observable.RequireAssertions())
+		observable.Assert(busy, observable.True())
+	})
+
+	if !idle.SpiedOnFailure || len(idle.Messages) != 1 || idle.Messages[0] != "expected at least one assertion, none ran" {
+		t.Errorf("expected idle test to fail, got %q", idle.Messages)
+	}
+	if busy.SpiedOnFailure {
+		t.Errorf("expected busy test to pass, got %q", busy.Messages)
+	}
+}
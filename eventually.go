@@ -0,0 +1,254 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// OkCtx evaluates p like [Predicate.Ok] but gives up once ctx is done, returning ctx's error; a result produced after ctx is done is discarded. Predicates built with [ThatCtx] observe ctx themselves and stop evaluating; other predicates keep running in the background until they return, but their result is discarded.
+func (p Predicate) OkCtx(ctx context.Context) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	if p.okCtx != nil {
+		ok := p.okCtx(ctx)
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		return ok, nil
+	}
+
+	done := make(chan bool, 1)
+	go func() { done <- p.Ok() }()
+
+	select {
+	case ok := <-done:
+		return ok, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// ThatCtx promotes a context-aware condition to a [Predicate]. When evaluated through [Predicate.OkCtx], for example by [Eventually] or [Within], f receives the evaluation's context and should return promptly once it is done. [Predicate.Ok] calls f once, with [context.Background].
+func ThatCtx(f func(ctx context.Context) bool) Predicate {
+	var (
+		mu  sync.Mutex
+		got bool
+	)
+
+	record := func(ok bool) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		got = ok
+		return ok
+	}
+
+	return Predicate{
+		ok:    memo(func() bool { return record(f(context.Background())) }),
+		okCtx: func(ctx context.Context) bool { return record(f(ctx)) },
+		msg: func() string {
+			mu.Lock()
+			defer mu.Unlock()
+			return sprintf("expected true, got %v", got)
+		},
+		desc: func() string { return "holds" },
+	}
+}
+
+// Eventually returns a [Predicate] that is ok when a predicate returned by f becomes ok within timeout. f is called again every interval until then; each probe is evaluated with [Predicate.OkCtx], so context-aware probes are cancelled when the timeout expires. On failure the number of attempts and the last probe's message are reported.
+func Eventually(timeout, interval time.Duration, f func() Predicate) Predicate {
+	var (
+		once sync.Once
+		res  pollResult
+	)
+
+	eval := func() {
+		once.Do(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			res = poll(ctx, interval, f)
+		})
+	}
+
+	return Predicate{
+		ok:   func() bool { eval(); return res.ok },
+		msg:  func() string { eval(); return res.message(sprintf("within %v", timeout)) },
+		desc: func() string { return sprintf("eventually within %v", timeout) },
+		asserted: func(tb testing.TB) {
+			eval()
+			recordPoll(tb, timeout, res)
+		},
+	}
+}
+
+// EventuallyCtx is like [Eventually] but polls until ctx is done rather than for a fixed timeout, so that polling respects the test's context and stops cleanly when it is cancelled. Use [DeadlineContext] to poll until shortly before the test binary's -timeout.
+func EventuallyCtx(ctx context.Context, interval time.Duration, f func() Predicate) Predicate {
+	var (
+		once    sync.Once
+		res     pollResult
+		timeout time.Duration
+	)
+
+	eval := func() {
+		once.Do(func() {
+			if deadline, ok := ctx.Deadline(); ok {
+				timeout = time.Until(deadline)
+			}
+			res = poll(ctx, interval, f)
+		})
+	}
+
+	bound := func() string {
+		if timeout > 0 {
+			return sprintf("within %v", timeout.Round(time.Millisecond))
+		}
+		return sprintf("before context was done")
+	}
+
+	return Predicate{
+		ok:   func() bool { eval(); return res.ok },
+		msg:  func() string { eval(); return res.message(bound()) },
+		desc: func() string { return "eventually" },
+		asserted: func(tb testing.TB) {
+			eval()
+			if timeout > 0 {
+				recordPoll(tb, timeout, res)
+			}
+		},
+	}
+}
+
+// DeadlineGrace is the time [DeadlineContext] leaves between its deadline and the test binary's, so that a timed-out assertion is reported before the binary is killed.
+var DeadlineGrace = 5 * time.Second
+
+// DeadlineContext returns a context that is cancelled [DeadlineGrace] before tb's deadline, as reported by [testing.T.Deadline], or when the test ends. Without a deadline, e.g. when tb has no Deadline method or the binary runs with -timeout=0, it is only cancelled when the test ends.
+func DeadlineContext(tb testing.TB) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if d, ok := tb.(interface{ Deadline() (time.Time, bool) }); ok {
+		if deadline, ok := d.Deadline(); ok {
+			cancel()
+			ctx, cancel = context.WithDeadline(context.Background(), deadline.Add(-DeadlineGrace))
+		}
+	}
+
+	tb.Cleanup(cancel)
+
+	return ctx
+}
+
+// Within returns a [Predicate] that is ok when p is ok and its evaluation completes within d. The evaluation uses [Predicate.OkCtx], so context-aware predicates are cancelled once d elapses. The result carries p's ID and name.
+func Within(d time.Duration, p Predicate) Predicate {
+	return within(d, p, false)
+}
+
+// AssertWithin asserts p like [Assert], but fails if evaluating p takes longer than d rather than letting a hung evaluation stall the test binary. When the evaluation times out, a dump of all goroutines taken at that moment is included in the failure message to show where it hung.
+func AssertWithin(tb testing.TB, d time.Duration, p Predicate) bool {
+	if !nilTB(tb) {
+		tb.Helper()
+	}
+
+	return Assert(tb, within(d, p, true))
+}
+
+// within implements [Within]; if dump is set, the failure message of a timed-out evaluation includes a goroutine dump.
+func within(d time.Duration, p Predicate, dump bool) Predicate {
+	var (
+		once  sync.Once
+		ok    bool
+		err   error
+		stack string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), d)
+			defer cancel()
+			if ok, err = p.OkCtx(ctx); err != nil && dump {
+				stack = goroutineDump()
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return ok },
+		msg: func() string {
+			eval()
+			switch {
+			case err != nil && stack != "":
+				return sprintf("expected evaluation to complete within %v: %v\n%s", d, err, stack)
+			case err != nil:
+				return sprintf("expected evaluation to complete within %v: %v", d, err)
+			}
+			return p.msg()
+		},
+		desc: func() string { return sprintf("%s within %v", p.Describe(), d) },
+		id:   p.id,
+		name: p.name,
+	}
+}
+
+// goroutineDump returns the stacks of all goroutines, growing the buffer until it fits, filtered by the configured [StackFilter].
+func goroutineDump() string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return sprintf("goroutines:\n%s", filterGoroutines(string(buf[:n])))
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// pollResult is the outcome of [poll].
+type pollResult struct {
+	ok       bool
+	attempts int
+	last     Predicate
+	err      error
+	elapsed  time.Duration
+}
+
+// poll evaluates fresh predicates from f every interval until one is ok or ctx is done.
+func poll(ctx context.Context, interval time.Duration, f func() Predicate) (res pollResult) {
+	start := time.Now()
+	defer func() { res.elapsed = time.Since(start) }()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			res.err = ctx.Err()
+			return res
+		case <-timer.C:
+		}
+
+		res.attempts++
+		res.last = f()
+		if res.ok, res.err = res.last.OkCtx(ctx); res.ok || res.err != nil {
+			return res
+		}
+
+		timer.Reset(interval)
+	}
+}
+
+// message describes a failed poll; bound describes the limit that was exceeded, e.g. "within 5s".
+func (r pollResult) message(bound string) string {
+	switch {
+	case r.attempts == 0:
+		return sprintf("condition not met %s: %v before first attempt", bound, r.err)
+	case r.err != nil && r.last.okCtx == nil:
+		return sprintf("condition not met %s after %d attempts: last attempt did not complete", bound, r.attempts)
+	default:
+		return sprintf("condition not met %s after %d attempts: %s", bound, r.attempts, r.last.Message())
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestEventually(t *testing.T) {
+	var n int32
+	counter := func() observable.Predicate { return observable.Equal(atomic.AddInt32(&n, 1), 3) }
+
+	testspy.ExpectPass(t, observable.Eventually(time.Second, time.Millisecond, counter))
+
+	p := observable.Eventually(20*time.Millisecond, time.Millisecond, func() observable.Predicate { return observable.False() })
+	testspy.ExpectFail(t, p)
+	if msg := p.Message(); !strings.Contains(msg, "condition not met within 20ms after") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+}
+
+func TestEventuallyCancelsProbe(t *testing.T) {
+	stopped := make(chan struct{})
+	probe := func() observable.Predicate {
+		return observable.ThatCtx(func(ctx context.Context) bool {
+			<-ctx.Done()
+			close(stopped)
+			return false
+		})
+	}
+
+	testspy.ExpectFail(t, observable.Eventually(10*time.Millisecond, time.Millisecond, probe))
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("probe was not cancelled")
+	}
+}
+
+func TestWithin(t *testing.T) {
+	testspy.ExpectPass(t, observable.Within(time.Second, observable.True()))
+	testspy.ExpectFail(t, observable.Within(time.Second, observable.False()))
+
+	slow := observable.Within(10*time.Millisecond, observable.ThatCtx(func(ctx context.Context) bool {
+		<-ctx.Done()
+		return true
+	}))
+	testspy.ExpectFail(t, slow)
+	if msg := slow.Message(); !strings.Contains(msg, "context deadline exceeded") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+}
+
+func TestOkCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if ok, err := observable.True().OkCtx(ctx); ok || err != context.Canceled {
+		t.Errorf("OkCtx on done context = %v, %v", ok, err)
+	}
+	if ok, err := observable.Not(observable.False()).OkCtx(context.Background()); !ok || err != nil {
+		t.Errorf("OkCtx = %v, %v", ok, err)
+	}
+}
+
+func TestEventuallyCtx(t *testing.T) {
+	var n int32
+	counter := func() observable.Predicate { return observable.Equal(atomic.AddInt32(&n, 1), 3) }
+
+	testspy.ExpectPass(t, observable.EventuallyCtx(observable.DeadlineContext(t), time.Millisecond, counter))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	p := observable.EventuallyCtx(ctx, time.Millisecond, observable.False)
+	testspy.ExpectFail(t, p)
+	if msg := p.Message(); !strings.Contains(msg, "condition not met before context was done after") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	testspy.ExpectFail(t, observable.EventuallyCtx(ctx, time.Millisecond, observable.False))
+}
+
+func TestDeadlineContext(t *testing.T) {
+	var ctx context.Context
+	t.Run("sub", func(t *testing.T) {
+		ctx = observable.DeadlineContext(t)
+		if deadline, ok := t.Deadline(); ok {
+			if got, _ := ctx.Deadline(); !got.Equal(deadline.Add(-observable.DeadlineGrace)) {
+				t.Errorf("expected deadline %v, got %v", deadline.Add(-observable.DeadlineGrace), got)
+			}
+		}
+	})
+
+	if ctx.Err() == nil {
+		t.Error("expected context to be cancelled when the test ends")
+	}
+}
+
+func TestAssertWithin(t *testing.T) {
+	spy := testspy.New(t)
+	if !observable.AssertWithin(spy, time.Second, observable.True()) {
+		t.Fatal("expected AssertWithin to pass")
+	}
+
+	observable.AssertWithin(spy, time.Second, observable.Named("check", observable.False()))
+
+	release := make(chan struct{})
+	defer close(release)
+	observable.AssertWithin(spy, 10*time.Millisecond, observable.That(func() bool { <-release; return true }))
+
+	if len(spy.Messages) != 2 {
+		t.Fatalf("expected two failures, got %q", spy.Messages)
+	}
+	if msg := spy.Messages[0]; !strings.HasPrefix(msg, "check (eventually_test.go:") {
+		t.Errorf("expected the predicate's name and location, got %q", msg)
+	}
+	if msg := spy.Messages[1]; !strings.Contains(msg, "expected evaluation to complete within 10ms") || !strings.Contains(msg, "goroutines:\ngoroutine ") {
+		t.Errorf("expected a goroutine dump, got %q", msg)
+	}
+	// The dump is filtered like other stack traces: the hung predicate's frame is kept, runtime and testing frames are not.
+	testspy.ExpectPass(t, observable.ContainsSubstring(spy.Messages[1], "renorm.dev/observable_test.TestAssertWithin.func"))
+	testspy.ExpectFail(t, observable.ContainsSubstring(spy.Messages[1], "runtime.gopark"))
+	testspy.ExpectFail(t, observable.ContainsSubstring(spy.Messages[1], "testing.tRunner"))
+}
+
+func TestThatCtxMemoized(t *testing.T) {
+	calls := 0
+	p := observable.ThatCtx(func(context.Context) bool { calls++; return false })
+
+	testspy.ExpectFail(t, p)
+	p.Ok()
+	_ = p.Message()
+	testspy.ExpectPass(t, observable.Equal(calls, 1))
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+// Package execheck provides predicates over commands run with [os/exec], for testing command-line tools.
+//
+// A command is run once, when the first predicate over it is evaluated; all predicates over the same [exec.Cmd] share that run. Its output is captured, in addition to any Stdout and Stderr writers already set, and reported on every failure.
+package execheck
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+
+	"renorm.dev/observable"
+)
+
+// result is the outcome of running a command.
+type result struct {
+	once           sync.Once
+	exitCode       int
+	err            error // set when the command could not be run or did not exit normally
+	stdout, stderr string
+}
+
+// capture records an output stream of a command, in addition to writing it to w, the writer the stream was set to before, if any. The result of the command is kept with its captures, so that it is released with the command.
+type capture struct {
+	w   io.Writer
+	buf bytes.Buffer
+	r   *result
+}
+
+func (c *capture) Write(p []byte) (int, error) {
+	c.buf.Write(p)
+	if c.w == nil {
+		return len(p), nil
+	}
+	return c.w.Write(p)
+}
+
+// installMu guards installing the captures of a command.
+var installMu sync.Mutex
+
+// run runs cmd on first use and returns its result.
+func run(cmd *exec.Cmd) *result {
+	installMu.Lock()
+	stdout, ok := cmd.Stdout.(*capture)
+	if !ok {
+		stdout = &capture{w: cmd.Stdout, r: &result{}}
+		cmd.Stdout = stdout
+		cmd.Stderr = &capture{w: cmd.Stderr, r: stdout.r}
+	}
+	stderr := cmd.Stderr.(*capture)
+	installMu.Unlock()
+
+	r := stdout.r
+	r.once.Do(func() {
+		if err := cmd.Run(); err != nil {
+			var exit *exec.ExitError
+			if errors.As(err, &exit) && exit.Exited() {
+				r.exitCode = exit.ExitCode()
+			} else {
+				r.err, r.exitCode = err, -1
+			}
+		}
+		r.stdout, r.stderr = stdout.buf.String(), stderr.buf.String()
+	})
+
+	return r
+}
+
+// describe renders the command and its output for failure messages.
+func (r *result) describe(cmd *exec.Cmd) string {
+	status := fmt.Sprintf("exit code %d", r.exitCode)
+	if r.err != nil {
+		status = r.err.Error()
+	}
+	return fmt.Sprintf("command: %s\nstatus: %s\nstdout:\n%s\nstderr:\n%s", cmd, status, indent(r.stdout), indent(r.stderr))
+}
+
+// indent indents every line of s for inclusion in a failure message.
+func indent(s string) string {
+	if s == "" {
+		return "    <empty>"
+	}
+	return "    " + strings.ReplaceAll(strings.TrimSuffix(s, "\n"), "\n", "\n    ")
+}
+
+// CommandSucceeds returns a [observable.Predicate] that is ok when cmd runs and exits with code 0.
+func CommandSucceeds(cmd *exec.Cmd) observable.Predicate {
+	return observable.NewPredicate(
+		func() bool { r := run(cmd); return r.err == nil && r.exitCode == 0 },
+		func() string { return "expected command to succeed\n" + run(cmd).describe(cmd) },
+	)
+}
+
+// ExitCode returns a [observable.Predicate] that is ok when cmd runs and exits with code want.
+func ExitCode(cmd *exec.Cmd, want int) observable.Predicate {
+	return observable.NewPredicate(
+		func() bool { r := run(cmd); return r.err == nil && r.exitCode == want },
+		func() string {
+			return fmt.Sprintf("expected command to exit with code %d\n%s", want, run(cmd).describe(cmd))
+		},
+	)
+}
+
+// StdoutContains returns a [observable.Predicate] that is ok when the standard output of cmd contains substr. The exit code is not checked.
+func StdoutContains(cmd *exec.Cmd, substr string) observable.Predicate {
+	return observable.NewPredicate(
+		func() bool { return strings.Contains(run(cmd).stdout, substr) },
+		func() string { return fmt.Sprintf("expected stdout to contain %q\n%s", substr, run(cmd).describe(cmd)) },
+	)
+}
+
+// StderrMatches returns a [observable.Predicate] that is ok when the standard error of cmd matches the regular expression pattern. The exit code is not checked. StderrMatches **panics** if pattern does not compile.
+func StderrMatches(cmd *exec.Cmd, pattern string) observable.Predicate {
+	re := regexp.MustCompile(pattern)
+
+	return observable.NewPredicate(
+		func() bool { return re.MatchString(run(cmd).stderr) },
+		func() string { return fmt.Sprintf("expected stderr to match %q\n%s", pattern, run(cmd).describe(cmd)) },
+	)
+}
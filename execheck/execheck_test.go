@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package execheck_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/execheck"
+	"renorm.dev/observable/internal/testspy"
+)
+
+// TestMain lets the test binary act as the command under test.
+func TestMain(m *testing.M) {
+	if code := os.Getenv("EXECHECK_HELPER"); code != "" {
+		fmt.Println("hello from stdout")
+		fmt.Fprintln(os.Stderr, "warning: disk 93% full")
+		if code == "fail" {
+			os.Exit(3)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func helper(mode string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), "EXECHECK_HELPER="+mode)
+	return cmd
+}
+
+func TestCommand(t *testing.T) {
+	var passthrough bytes.Buffer
+	ok := helper("ok")
+	ok.Stdout = &passthrough
+
+	testspy.ExpectPass(t, execheck.CommandSucceeds(ok))
+	testspy.ExpectPass(t, execheck.ExitCode(ok, 0))
+	testspy.ExpectPass(t, execheck.StdoutContains(ok, "hello"))
+	testspy.ExpectPass(t, execheck.StderrMatches(ok, `disk \d+% full`))
+	testspy.ExpectFail(t, execheck.StdoutContains(ok, "goodbye"))
+	if passthrough.String() != "hello from stdout\n" {
+		t.Errorf("expected existing Stdout to receive output, got %q", passthrough.String())
+	}
+
+	fail := helper("fail")
+	testspy.ExpectFail(t, execheck.CommandSucceeds(fail))
+	testspy.ExpectPass(t, execheck.ExitCode(fail, 3))
+	want := "expected command to succeed\ncommand: " + fail.String() + "\nstatus: exit code 3\nstdout:\n    hello from stdout\nstderr:\n    warning: disk 93% full"
+	if msg := execheck.CommandSucceeds(fail).Message(); msg != want {
+		t.Errorf("got message\n%s\nwant\n%s", msg, want)
+	}
+
+	missing := exec.Command("/nonexistent/tool")
+	testspy.ExpectFail(t, execheck.CommandSucceeds(missing))
+	testspy.ExpectFail(t, execheck.ExitCode(missing, 0))
+
+	testspy.ExpectPass(t, observable.Panics(func() { execheck.StderrMatches(ok, "(") }))
+}
+
+func TestCommandResultReleased(t *testing.T) {
+	var released int32
+	func() {
+		cmd := helper("ok")
+		testspy.ExpectPass(t, execheck.CommandSucceeds(cmd))
+		runtime.SetFinalizer(cmd, func(*exec.Cmd) { atomic.StoreInt32(&released, 1) })
+	}()
+
+	observable.Assert(t, observable.Eventually(5*time.Second, 10*time.Millisecond, func() observable.Predicate {
+		runtime.GC()
+		return observable.That(atomic.LoadInt32(&released) == 1)
+	}))
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Asserter is bound to a [testing.TB] so that predicates can be asserted without passing the TB to every call. An Asserter is safe for concurrent use to the same extent as its TB.
+type Asserter struct {
+	tb     testing.TB
+	prefix string
+}
+
+// Expect returns an [Asserter] bound to tb.
+func Expect(tb testing.TB) *Asserter {
+	return &Asserter{tb: tb}
+}
+
+// With returns an [Asserter] bound to tb whose failure messages are all prefixed with the context formatted from format and args, e.g. With(t, "creating order %d", id) reports "creating order 7: expected ...".
+func With(tb testing.TB, format string, args ...any) *Asserter {
+	return &Asserter{tb: tb, prefix: fmt.Sprintf(format, args...) + ": "}
+}
+
+// With returns a copy of the Asserter whose failure messages are additionally prefixed with the context formatted from format and args, after any context the Asserter already has.
+func (a *Asserter) With(format string, args ...any) *Asserter {
+	c := *a
+	c.prefix += fmt.Sprintf(format, args...) + ": "
+	return &c
+}
+
+// TB returns the [testing.TB] the Asserter reports to.
+func (a *Asserter) TB() testing.TB { return a.tb }
+
+// That behaves like [Assert] on the bound TB.
+func (a *Asserter) That(p Predicate) bool {
+	if nilTB(a.tb) {
+		return assertNil(p, a.prefix, func(p Predicate) string { return p.msg() })
+	}
+
+	a.tb.Helper()
+	return assert(a.tb, p, a.prefix, func(p Predicate) string { return p.msg() })
+}
+
+// Thatf behaves like [Assertf] on the bound TB.
+func (a *Asserter) Thatf(p Predicate, format string, args ...any) bool {
+	if nilTB(a.tb) {
+		return assertNil(p, a.prefix, func(p Predicate) string { return formatf(p, format, args) })
+	}
+
+	a.tb.Helper()
+	return assert(a.tb, p, a.prefix, func(p Predicate) string { return formatf(p, format, args) })
+}
+
+// Require behaves like [Asserter.That] but stops the test with FailNow when p is not ok.
+func (a *Asserter) Require(p Predicate) {
+	if nilTB(a.tb) {
+		a.That(p)
+		return
+	}
+
+	a.tb.Helper()
+
+	if !a.That(p) {
+		a.tb.FailNow()
+	}
+}
+
+// Requiref behaves like [Asserter.Thatf] but stops the test with FailNow when p is not ok.
+func (a *Asserter) Requiref(p Predicate, format string, args ...any) {
+	if nilTB(a.tb) {
+		a.Thatf(p, format, args...)
+		return
+	}
+
+	a.tb.Helper()
+
+	if !a.Thatf(p, format, args...) {
+		a.tb.FailNow()
+	}
+}
+
+// Clone returns a copy of the Asserter bound to tb, typically a subtest's *testing.T.
+func (a *Asserter) Clone(tb testing.TB) *Asserter {
+	c := *a
+	c.tb = tb
+	return &c
+}
+
+// Run runs f as a subtest of the bound TB with an Asserter cloned for the subtest, and reports whether it succeeded. Run **panics** if the bound TB does not support subtests via Run(string, func(*testing.T)) bool.
+func (a *Asserter) Run(name string, f func(o *Asserter)) bool {
+	a.tb.Helper()
+
+	r, ok := a.tb.(interface {
+		Run(string, func(*testing.T)) bool
+	})
+	if !ok {
+		panic(fmt.Sprintf("Asserter.Run: %T does not support subtests", a.tb))
+	}
+
+	return r.Run(name, func(t *testing.T) { f(a.Clone(t)) })
+}
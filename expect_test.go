@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestExpect(t *testing.T) {
+	spy := testspy.New(t)
+	o := observable.Expect(spy)
+
+	if !o.That(observable.Equal(1, 1)) || spy.SpiedOnFailure {
+		t.Fatal("expected That with passing predicate to pass")
+	}
+
+	if o.Thatf(observable.Equal(1, 2), "custom %d", 7) || spy.Messages[0] != "custom 7" {
+		t.Errorf("expected Thatf to fail with custom message, got %q", spy.Messages)
+	}
+
+	o.Require(observable.True())
+	testspy.ExpectPass(t, observable.Panics(func() { o.Require(observable.False()) }))
+
+	if o.Clone(t).TB() != t {
+		t.Error("expected Clone to bind the new TB")
+	}
+	testspy.ExpectPass(t, observable.Panics(func() { o.Run("sub", func(*observable.Asserter) {}) }))
+}
+
+func TestExpectRun(t *testing.T) {
+	o := observable.Expect(t)
+
+	for _, n := range []int{1, 2, 3} {
+		n := n
+		o.Run("parallel", func(o *observable.Asserter) {
+			o.TB().(*testing.T).Parallel()
+			o.Require(observable.That(n > 0))
+		})
+	}
+}
+
+func TestWith(t *testing.T) {
+	spy := testspy.New(t)
+	o := observable.With(spy, "creating order %d", 7)
+
+	o.That(observable.True())
+	o.That(observable.Equal(1, 2))
+	o.With("line %d", 2).Thatf(observable.False(), "custom")
+
+	want := []string{
+		"creating order 7: " + observable.Equal(1, 2).Message(),
+		"creating order 7: line 2: custom",
+	}
+	if len(spy.Messages) != len(want) || spy.Messages[0] != want[0] || spy.Messages[1] != want[1] {
+		t.Errorf("got messages %q, want %q", spy.Messages, want)
+	}
+
+	if o.Clone(t).With("x").TB() != t {
+		t.Error("expected With to keep the bound TB")
+	}
+}
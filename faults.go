@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import "sync"
+
+// UnderFaults returns a [Predicate] that is ok when the invariant built by p holds both with faults disabled and with faults enabled. inject is called with true to enable fault injection and with false to disable it; faults are always disabled again after evaluation.
+//
+// p is called once per mode, so it should construct a fresh predicate that observes the system's current behavior. Degradation that is acceptable under faults should be expressed within p itself.
+func UnderFaults(inject func(enable bool), p func() Predicate) Predicate {
+	var (
+		once             sync.Once
+		healthy, faulted Predicate
+		healthyOk        bool
+		faultedOk        bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			defer inject(false)
+
+			inject(false)
+			healthy = p()
+			healthyOk = healthy.Ok()
+
+			inject(true)
+			faulted = p()
+			faultedOk = faulted.Ok()
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return healthyOk && faultedOk },
+		msg: func() string {
+			eval()
+			switch {
+			case !healthyOk && !faultedOk:
+				return sprintf("invariant violated without faults: %s\ninvariant violated with faults: %s", healthy.Message(), faulted.Message())
+			case !healthyOk:
+				return sprintf("invariant violated without faults: %s", healthy.Message())
+			case !faultedOk:
+				return sprintf("invariant violated with faults: %s", faulted.Message())
+			default:
+				return sprintf("invariant held with and without faults")
+			}
+		},
+	}
+}
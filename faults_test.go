@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"errors"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestUnderFaults(t *testing.T) {
+	var faulty bool
+	inject := func(enable bool) { faulty = enable }
+
+	errUnavailable := errors.New("unavailable")
+	fetch := func() (string, error) {
+		if faulty {
+			return "", errUnavailable
+		}
+		return "data", nil
+	}
+	fetchWithFallback := func() (string, error) {
+		if v, err := fetch(); err == nil {
+			return v, nil
+		}
+		return "cached", nil
+	}
+
+	// Errors are acceptable degradation, but only the designated one.
+	testspy.ExpectPass(t, observable.UnderFaults(inject, func() observable.Predicate {
+		_, err := fetch()
+		return observable.Any(observable.Nil(err), observable.ErrorIs(err, errUnavailable))
+	}))
+
+	testspy.ExpectPass(t, observable.UnderFaults(inject, func() observable.Predicate {
+		_, err := fetchWithFallback()
+		return observable.Nil(err)
+	}))
+
+	testspy.ExpectFail(t, observable.UnderFaults(inject, func() observable.Predicate {
+		v, _ := fetch()
+		return observable.Equal(v, "data")
+	}))
+
+	if faulty {
+		t.Error("expected faults to be disabled after evaluation")
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// FileExists returns a [Predicate] that is ok when path exists and is not a directory.
+func FileExists(path string) Predicate {
+	var (
+		once sync.Once
+		info fs.FileInfo
+		err  error
+	)
+
+	eval := func() { once.Do(func() { info, err = os.Stat(path) }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return err == nil && !info.IsDir() },
+		msg: func() string {
+			eval()
+			if err != nil {
+				return sprintf("expected file %s to exist: %v", path, err)
+			}
+			return sprintf("expected %s to be a file, got a directory", path)
+		},
+		neg: func() string { return sprintf("expected file %s not to exist", path) },
+	}
+}
+
+// DirExists returns a [Predicate] that is ok when path exists and is a directory.
+func DirExists(path string) Predicate {
+	var (
+		once sync.Once
+		info fs.FileInfo
+		err  error
+	)
+
+	eval := func() { once.Do(func() { info, err = os.Stat(path) }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return err == nil && info.IsDir() },
+		msg: func() string {
+			eval()
+			if err != nil {
+				return sprintf("expected directory %s to exist: %v", path, err)
+			}
+			return sprintf("expected %s to be a directory, got mode %v", path, info.Mode())
+		},
+		neg: func() string { return sprintf("expected directory %s not to exist", path) },
+	}
+}
+
+// FileContains returns a [Predicate] that is ok when the file at path can be read and its contents contain substr.
+func FileContains(path, substr string) Predicate {
+	var (
+		once sync.Once
+		data []byte
+		err  error
+	)
+
+	eval := func() { once.Do(func() { data, err = os.ReadFile(path) }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return err == nil && bytes.Contains(data, []byte(substr)) },
+		msg: func() string {
+			eval()
+			if err != nil {
+				return sprintf("expected file %s to contain %q: %v", path, substr, err)
+			}
+			return sprintf("expected file %s to contain %q, got %q", path, substr, truncateUTF8(string(data), 1024))
+		},
+	}
+}
+
+// FileEqual returns a [Predicate] that is ok when the contents of the file at path are identical to want. The file is read in chunks and reading stops at the first difference, so large files are never loaded into memory. On failure textual content of up to 64 KiB is reported as a line diff, and other content by its first differing byte offset.
+func FileEqual(path string, want []byte) Predicate {
+	return filesEqual(path, "", func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(want)), nil })
+}
+
+// FilesEqual returns a [Predicate] that is ok when the files at path and wantPath have identical contents. Both files are read in chunks as for [FileEqual], so multi-gigabyte artifacts can be compared in constant memory.
+func FilesEqual(path, wantPath string) Predicate {
+	return filesEqual(path, wantPath, func() (io.ReadCloser, error) { return os.Open(wantPath) })
+}
+
+// filesEqual implements [FileEqual] and [FilesEqual]; openWant opens the wanted contents, read from wantPath if it is not empty.
+func filesEqual(path, wantPath string, openWant func() (io.ReadCloser, error)) Predicate {
+	var (
+		once    sync.Once
+		res     streamComparison
+		err     error
+		errPath string // the file that could not be opened
+	)
+
+	eval := func() {
+		once.Do(func() {
+			var want, got io.ReadCloser
+			if want, err = openWant(); err != nil {
+				errPath = wantPath
+				return
+			}
+			defer want.Close()
+			if got, err = os.Open(path); err != nil {
+				errPath = path
+				return
+			}
+			defer got.Close()
+
+			res = compareStreams(want, got)
+			err = res.err
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return err == nil && res.equal },
+		msg: func() string {
+			eval()
+			switch {
+			case errPath != "":
+				return sprintf("opening %s: %v", errPath, err)
+			case err != nil:
+				return sprintf("comparing %s: %v", path, err)
+			}
+			return sprintf("expected file %s to have the wanted contents\n%s", path, res.diff())
+		},
+	}
+}
+
+// FileMode returns a [Predicate] that is ok when the permission bits of path equal those of mode. When mode also includes type bits (e.g. [fs.ModeDir]), those must match too.
+func FileMode(path string, mode fs.FileMode) Predicate {
+	var (
+		once sync.Once
+		info fs.FileInfo
+		err  error
+	)
+
+	mask := fs.ModePerm
+	if mode&fs.ModeType != 0 {
+		mask |= fs.ModeType
+	}
+
+	eval := func() { once.Do(func() { info, err = os.Stat(path) }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return err == nil && info.Mode()&mask == mode },
+		msg: func() string {
+			eval()
+			if err != nil {
+				return sprintf("expected %s to have mode %v: %v", path, mode, err)
+			}
+			return sprintf("expected %s to have mode %v, got %v", path, mode, info.Mode()&mask)
+		},
+	}
+}
+
+// FSContains returns a [Predicate] that is ok when path exists in fsys.
+func FSContains(fsys fs.FS, path string) Predicate {
+	var (
+		once sync.Once
+		err  error
+	)
+
+	eval := func() { once.Do(func() { _, err = fs.Stat(fsys, path) }) }
+
+	return Predicate{
+		ok:  func() bool { eval(); return err == nil },
+		msg: func() string { eval(); return sprintf("expected file system to contain %s: %v", path, err) },
+		neg: func() string { return sprintf("expected file system not to contain %s", path) },
+	}
+}
+
+// IsSymlinkTo returns a [Predicate] that is ok when path is a symbolic link whose target, as returned by [os.Readlink], is target. The link itself is inspected, not the file it points to.
+func IsSymlinkTo(path, target string) Predicate {
+	var (
+		once sync.Once
+		info fs.FileInfo
+		got  string
+		err  error
+	)
+
+	eval := func() {
+		once.Do(func() {
+			if info, err = os.Lstat(path); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+				got, err = os.Readlink(path)
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return err == nil && info.Mode()&fs.ModeSymlink != 0 && got == target },
+		msg: func() string {
+			eval()
+			switch {
+			case err != nil:
+				return sprintf("expected %s to be a symlink to %s: %v", path, target, err)
+			case info.Mode()&fs.ModeSymlink == 0:
+				return sprintf("expected %s to be a symlink to %s, got mode %v", path, target, info.Mode())
+			default:
+				return sprintf("expected %s to be a symlink to %s, links to %s", path, target, got)
+			}
+		},
+		desc: func() string { return fmt.Sprintf("symlink to %s", target) },
+	}
+}
+
+// SameInode returns a [Predicate] that is ok when a and b refer to the same file, as determined by [os.SameFile], e.g. because one is a hard link to the other. Symbolic links are followed.
+func SameInode(a, b string) Predicate {
+	var (
+		once   sync.Once
+		ia, ib fs.FileInfo
+		err    error
+	)
+
+	eval := func() {
+		once.Do(func() {
+			if ia, err = os.Stat(a); err == nil {
+				ib, err = os.Stat(b)
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return err == nil && os.SameFile(ia, ib) },
+		msg: func() string {
+			eval()
+			if err != nil {
+				return sprintf("expected %s and %s to be the same file: %v", a, b, err)
+			}
+			return sprintf("expected %s and %s to be the same file", a, b)
+		},
+		neg: func() string { return sprintf("expected %s and %s to be different files", a, b) },
+	}
+}
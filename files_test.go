@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"testing/fstest"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestFileChecks(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(file, []byte("hello\nworld\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	testspy.ExpectPass(t, observable.FileExists(file))
+	testspy.ExpectFail(t, observable.FileExists(dir))
+	testspy.ExpectFail(t, observable.FileExists(missing))
+	testspy.ExpectPass(t, observable.Not(observable.FileExists(missing)))
+
+	testspy.ExpectPass(t, observable.DirExists(dir))
+	testspy.ExpectFail(t, observable.DirExists(file))
+	testspy.ExpectFail(t, observable.DirExists(missing))
+
+	testspy.ExpectPass(t, observable.FileContains(file, "world"))
+	testspy.ExpectFail(t, observable.FileContains(file, "moon"))
+	testspy.ExpectFail(t, observable.FileContains(missing, "world"))
+
+	testspy.ExpectPass(t, observable.FileEqual(file, []byte("hello\nworld\n")))
+	testspy.ExpectFail(t, observable.FileEqual(file, []byte("hello\nmoon\n")))
+	testspy.ExpectFail(t, observable.FileEqual(missing, nil))
+	testspy.ExpectPass(t, observable.ContainsSubstring(observable.FileEqual(file, []byte("hello\nmoon\n")).Message(), "+   2: world"))
+
+	same := filepath.Join(dir, "same.txt")
+	if err := os.WriteFile(same, []byte("hello\nworld\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	testspy.ExpectPass(t, observable.FilesEqual(file, same))
+	testspy.ExpectFail(t, observable.FilesEqual(file, missing))
+	testspy.ExpectFail(t, observable.FilesEqual(missing, same))
+	testspy.ExpectPass(t, observable.HasPrefix(observable.FilesEqual(file, missing).Message(), "opening "+missing+": "))
+	testspy.ExpectPass(t, observable.HasPrefix(observable.FilesEqual(missing, same).Message(), "opening "+missing+": "))
+	testspy.ExpectPass(t, observable.HasPrefix(observable.FileEqual(missing, nil).Message(), "opening "+missing+": "))
+
+	if runtime.GOOS != "windows" {
+		testspy.ExpectPass(t, observable.FileMode(file, 0o600))
+		testspy.ExpectFail(t, observable.FileMode(file, 0o644))
+		if err := os.Chmod(dir, 0o700); err != nil {
+			t.Fatal(err)
+		}
+		testspy.ExpectPass(t, observable.FileMode(dir, fs.ModeDir|0o700))
+		testspy.ExpectFail(t, observable.FileMode(file, fs.ModeDir|0o600))
+	}
+	testspy.ExpectFail(t, observable.FileMode(missing, 0o600))
+}
+
+func TestFSContains(t *testing.T) {
+	fsys := fstest.MapFS{"a/b.txt": {Data: []byte("b")}}
+
+	testspy.ExpectPass(t, observable.FSContains(fsys, "a/b.txt"))
+	testspy.ExpectPass(t, observable.FSContains(fsys, "a"))
+	testspy.ExpectFail(t, observable.FSContains(fsys, "a/c.txt"))
+	testspy.ExpectPass(t, observable.FSContains(os.DirFS("."), "files.go"))
+}
+
+func TestLinkChecks(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	hard := filepath.Join(dir, "hard")
+	if err := os.Link(file, hard); err != nil {
+		t.Skipf("hard links not supported: %v", err)
+	}
+	testspy.ExpectPass(t, observable.SameInode(file, hard))
+	testspy.ExpectFail(t, observable.SameInode(file, dir))
+	testspy.ExpectFail(t, observable.SameInode(file, filepath.Join(dir, "missing")))
+
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink("file", link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	testspy.ExpectPass(t, observable.IsSymlinkTo(link, "file"))
+	testspy.ExpectPass(t, observable.SameInode(link, file))
+	testspy.ExpectFail(t, observable.IsSymlinkTo(link, "other"))
+	testspy.ExpectFail(t, observable.IsSymlinkTo(file, "file"))
+	testspy.ExpectFail(t, observable.IsSymlinkTo(filepath.Join(dir, "missing"), "file"))
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+// Package fuzz provides helpers for reproducing fuzzing failures outside the fuzzing engine.
+package fuzz
+
+// Minimize returns a small input for which fails still reports true, obtained by repeatedly removing chunks of input (delta debugging). The result is 1-minimal: removing any single byte makes fails report false.
+//
+// If fails(input) is false, input is returned unchanged. input is never modified.
+func Minimize(input []byte, fails func([]byte) bool) []byte {
+	if !fails(input) {
+		return input
+	}
+
+	current := append([]byte(nil), input...)
+
+	for n := 2; len(current) > 0; {
+		chunk := (len(current) + n - 1) / n
+		reduced := false
+
+		for start := 0; start < len(current); start += chunk {
+			end := start + chunk
+			if end > len(current) {
+				end = len(current)
+			}
+
+			candidate := make([]byte, 0, len(current)-(end-start))
+			candidate = append(candidate, current[:start]...)
+			candidate = append(candidate, current[end:]...)
+
+			if fails(candidate) {
+				current = candidate
+				reduced = true
+				break
+			}
+		}
+
+		switch {
+		case reduced:
+			if n > 2 {
+				n--
+			}
+		case chunk == 1:
+			return current
+		default:
+			n *= 2
+			if n > len(current) {
+				n = len(current)
+			}
+		}
+	}
+
+	return current
+}
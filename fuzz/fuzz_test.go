@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package fuzz_test
+
+import (
+	"bytes"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/fuzz"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestMinimize(t *testing.T) {
+	input := bytes.Repeat([]byte("noise "), 1000)
+	input = append(input, []byte("<crash>")...)
+	input = append(input, bytes.Repeat([]byte(" more"), 1000)...)
+
+	calls := 0
+	fails := func(b []byte) bool {
+		calls++
+		return bytes.Contains(b, []byte("<crash>"))
+	}
+
+	got := fuzz.Minimize(input, fails)
+	testspy.ExpectPass(t, observable.Equal(string(got), "<crash>"))
+	testspy.ExpectPass(t, observable.That(calls < 2000))
+
+	// Inputs that do not fail are returned unchanged.
+	testspy.ExpectPass(t, observable.Equal(string(fuzz.Minimize([]byte("fine"), fails)), "fine"))
+
+	// Order-dependent failures: requires both an 'a' and a later 'b'.
+	ordered := func(b []byte) bool {
+		i := bytes.IndexByte(b, 'a')
+		return i >= 0 && bytes.IndexByte(b[i:], 'b') >= 0
+	}
+	testspy.ExpectPass(t, observable.Equal(string(fuzz.Minimize([]byte("xxbxxaxxxxbxx"), ordered)), "ab"))
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// updateGolden is registered under a namespaced name so that it does not clash with an -update flag defined by the package under test.
+var updateGolden = flag.Bool("observable.update", false, "rewrite golden files used by observable.MatchesGolden")
+
+// MatchesGolden asserts that got is identical to the contents of the golden file at path and records an error on the [testing.TB] when it is not. Textual content is reported as a line diff, binary content by its first differing byte offset.
+//
+// When tests run with -observable.update, or with an -update bool flag defined by the test package, the golden file is (re)written with got instead and the assertion passes.
+//
+// As with [Assert], tb may be nil, in which case MatchesGolden **panics** with the failure message on failure.
+func MatchesGolden(tb testing.TB, got []byte, path string) bool {
+	if !nilTB(tb) {
+		tb.Helper()
+	}
+
+	if shouldUpdateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return Assert(tb, goldenFailure(sprintf("updating golden file %s: %v", path, err)))
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			return Assert(tb, goldenFailure(sprintf("updating golden file %s: %v", path, err)))
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		return Assert(tb, goldenFailure(sprintf("reading golden file: %v (run with -observable.update to create it)", err)))
+	}
+
+	return Assert(tb, goldenEqual(got, want, path))
+}
+
+// goldenFailure returns a [Predicate] that is never ok, for failures to read or write the golden file.
+func goldenFailure(msg string) Predicate {
+	return Predicate{ok: func() bool { return false }, msg: func() string { return msg }}
+}
+
+// goldenEqual returns a [Predicate] that is ok when got and want are byte-for-byte identical.
+func goldenEqual(got, want []byte, path string) Predicate {
+	var (
+		once sync.Once
+		diff string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			if !bytes.Equal(got, want) {
+				diff = contentDiff(want, got)
+			}
+		})
+	}
+
+	return Predicate{
+		ok:  memo(func() bool { return bytes.Equal(got, want) }),
+		msg: func() string { eval(); return sprintf("output does not match golden file %s\n%s", path, diff) },
+	}
+}
+
+// shouldUpdateGolden reports whether golden files should be rewritten rather than compared.
+func shouldUpdateGolden() bool {
+	if *updateGolden {
+		return true
+	}
+
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+
+	update, err := strconv.ParseBool(f.Value.String())
+
+	return err == nil && update
+}
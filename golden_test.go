@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestMatchesGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.golden")
+	if err := os.WriteFile(path, []byte("a\nb\nc\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if !observable.MatchesGolden(t, []byte("a\nb\nc\n"), path) {
+		t.Error("expected identical output to match golden file")
+	}
+
+	spy := testspy.New(t)
+	if observable.MatchesGolden(spy, []byte("a\nx\nc\n"), path) || !spy.SpiedOnFailure {
+		t.Fatal("expected differing output to fail")
+	}
+	if msg := spy.Messages[0]; !strings.Contains(msg, "-   2: b") || !strings.Contains(msg, "+   2: x") {
+		t.Errorf("expected line diff in message, got %q", msg)
+	}
+
+	spy = testspy.New(t)
+	if observable.MatchesGolden(spy, []byte{0, 1, 2}, path) || !strings.Contains(spy.Messages[0], "byte offset 0") {
+		t.Errorf("expected binary diff for binary output, got %q", spy.Messages)
+	}
+
+	spy = testspy.New(t)
+	if observable.MatchesGolden(spy, nil, filepath.Join(t.TempDir(), "missing.golden")) || !spy.SpiedOnFailure {
+		t.Error("expected missing golden file to fail")
+	}
+}
+
+func TestMatchesGoldenUpdate(t *testing.T) {
+	if err := flag.Set("observable.update", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer flag.Set("observable.update", "false")
+
+	path := filepath.Join(t.TempDir(), "nested", "out.golden")
+	if !observable.MatchesGolden(t, []byte("fresh"), path) {
+		t.Fatal("expected update to pass")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "fresh" {
+		t.Errorf("expected golden file to be rewritten, got %q (%v)", data, err)
+	}
+}
+
+func TestMatchesGoldenNilTB(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.golden")
+	if err := os.WriteFile(path, []byte("a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !observable.MatchesGolden(nil, []byte("a\n"), path) {
+		t.Error("expected nil-TB golden match to pass")
+	}
+
+	defer func() {
+		if r, _ := recover().(string); !strings.HasPrefix(r, "reading golden file: ") {
+			t.Errorf("got panic %q", r)
+		}
+	}()
+	observable.MatchesGolden((*testing.T)(nil), nil, filepath.Join(t.TempDir(), "missing.golden"))
+}
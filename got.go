@@ -0,0 +1,22 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import "testing"
+
+// Got asserts the predicate built by each of preds for v, as [Assert] does, and returns v so that it can be checked and used in a single expression:
+//
+//	cfg := observable.Got(t, LoadConfig(), func(c Config) observable.Predicate { return observable.Equal(c.Port, 8080) })
+//
+// Every predicate is asserted even when an earlier one fails.
+func Got[T any](tb testing.TB, v T, preds ...func(T) Predicate) T {
+	if !nilTB(tb) {
+		tb.Helper()
+	}
+
+	for _, pred := range preds {
+		Assert(tb, pred(v))
+	}
+
+	return v
+}
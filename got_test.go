@@ -0,0 +1,24 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestGot(t *testing.T) {
+	positive := func(n int) observable.Predicate { return observable.That(n > 0) }
+	even := func(n int) observable.Predicate { return observable.Equal(n%2, 0) }
+
+	if n := observable.Got(t, 4, positive, even); n != 4 {
+		t.Errorf("expected Got to return its value, got %d", n)
+	}
+
+	spy := testspy.New(t)
+	if n := observable.Got(spy, -3, positive, even, observable.Zero[int]); n != -3 || len(spy.Messages) != 3 {
+		t.Errorf("expected all three predicates to fail and -3 to be returned, got %d and %q", n, spy.Messages)
+	}
+}
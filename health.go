@@ -0,0 +1,21 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import "fmt"
+
+// AsHealthCheck adapts an invariant into a health check of the func() error form used by common health-check frameworks, so that readiness probes and tests can share the same predicates. Each call builds a fresh predicate with p and evaluates it with [Check]; on failure the error is the failure message prefixed with name. A panicking predicate is reported as an error rather than crashing the caller.
+func AsHealthCheck(name string, p func() Predicate) func() error {
+	return func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = errorf("%s: panic: %v", name, r)
+			}
+		}()
+
+		if err := Check(p()); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+
+	"renorm.dev/observable"
+)
+
+func TestAsHealthCheck(t *testing.T) {
+	queue := []int{1, 2}
+	check := observable.AsHealthCheck("queue", func() observable.Predicate { return observable.LengthAtMost(queue, 2) })
+
+	if err := check(); err != nil {
+		t.Errorf("expected healthy, got %v", err)
+	}
+
+	queue = append(queue, 3)
+	if err := check(); err == nil || err.Error() != "queue: expected len <= 2, got 3: [1 2 3]" {
+		t.Errorf("got error %v", err)
+	}
+
+	broken := observable.AsHealthCheck("broken", func() observable.Predicate { panic("boom") })
+	if err := broken(); err == nil || err.Error() != "broken: panic: boom" {
+		t.Errorf("got error %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// RequestMethod returns a [Predicate] that is ok when r's method is want, e.g. [http.MethodPost].
+func RequestMethod(r *http.Request, want string) Predicate {
+	return Predicate{
+		ok:   memo(func() bool { return r.Method == want }),
+		msg:  func() string { return sprintf("expected request method %s, got %s %s", want, r.Method, r.URL) },
+		desc: func() string { return "method " + want },
+	}
+}
+
+// RequestPath returns a [Predicate] that is ok when the path of r's URL is want.
+func RequestPath(r *http.Request, want string) Predicate {
+	return Predicate{
+		ok:   memo(func() bool { return r.URL.Path == want }),
+		msg:  func() string { return sprintf("expected request path %q, got %q", want, r.URL.Path) },
+		desc: func() string { return "path " + want },
+	}
+}
+
+// RequestQueryParam returns a [Predicate] that is ok when the query parameter key of r's URL is present with the value want. If the parameter is repeated, its first value is compared.
+func RequestQueryParam(r *http.Request, key, want string) Predicate {
+	query := r.URL.Query()
+
+	return Predicate{
+		ok: memo(func() bool { return query.Has(key) && query.Get(key) == want }),
+		msg: func() string {
+			if !query.Has(key) {
+				return sprintf("expected query parameter %s=%q, parameter missing from %q", key, want, r.URL.RawQuery)
+			}
+			return sprintf("expected query parameter %s=%q, got %q", key, want, query[key])
+		},
+		desc: func() string { return fmt.Sprintf("query %s=%s", key, want) },
+	}
+}
+
+// RequestHeader returns a [Predicate] that is ok when r has the header key with the value want. key is canonicalized as by [http.Header.Get]; if the header is repeated, its first value is compared.
+func RequestHeader(r *http.Request, key, want string) Predicate {
+	values := r.Header.Values(key)
+
+	return Predicate{
+		ok: memo(func() bool { return len(values) > 0 && values[0] == want }),
+		msg: func() string {
+			if len(values) == 0 {
+				return sprintf("expected header %s: %q, header missing", http.CanonicalHeaderKey(key), want)
+			}
+			return sprintf("expected header %s: %q, got %q", http.CanonicalHeaderKey(key), want, values)
+		},
+		desc: func() string { return fmt.Sprintf("header %s: %s", http.CanonicalHeaderKey(key), want) },
+	}
+}
+
+// RequestBodyJSON returns a [Predicate] that is ok when r's body is a JSON document equal to want, compared as by [JSONPath] with the path "$". The body is read on first evaluation and replaced with an unread copy, so the handler can still read it.
+func RequestBodyJSON(r *http.Request, want any) Predicate {
+	var (
+		once sync.Once
+		body []byte
+		err  error
+		p    Predicate
+	)
+
+	eval := func() {
+		once.Do(func() {
+			if r.Body == nil {
+				r.Body = http.NoBody
+			}
+			body, err = io.ReadAll(r.Body)
+			r.Body.Close()
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			p = JSONPath(body, "$", want)
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return err == nil && p.Ok() },
+		msg: func() string {
+			eval()
+			if err != nil {
+				return sprintf("expected request body to be JSON %s: reading body: %v", compactJSON(stableValue(want)), err)
+			}
+			return sprintf("expected request body to match: %s", p.msg())
+		},
+		desc: func() string { return "body " + compactJSON(stableValue(want)) },
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestRequestPredicates(t *testing.T) {
+	var captured *http.Request
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testspy.ExpectPass(t, observable.RequestBodyJSON(r, map[string]any{"id": 7, "tags": []string{"a"}}))
+		b, _ := io.ReadAll(r.Body)
+		body, captured = string(b), r
+	}))
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/orders?dry=true&page=2&page=3", strings.NewReader(`{"tags": ["a"], "id": 7.0}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if body != `{"tags": ["a"], "id": 7.0}` {
+		t.Errorf("handler could not re-read body, got %q", body)
+	}
+
+	r := captured
+	testspy.ExpectPass(t, observable.RequestMethod(r, http.MethodPost))
+	testspy.ExpectFail(t, observable.RequestMethod(r, http.MethodGet))
+	testspy.ExpectPass(t, observable.RequestPath(r, "/orders"))
+	testspy.ExpectFail(t, observable.RequestPath(r, "/order"))
+	testspy.ExpectPass(t, observable.RequestQueryParam(r, "page", "2"))
+	testspy.ExpectFail(t, observable.RequestQueryParam(r, "page", "3"))
+	testspy.ExpectFail(t, observable.RequestQueryParam(r, "limit", "1"))
+	testspy.ExpectPass(t, observable.RequestHeader(r, "content-type", "application/json"))
+	testspy.ExpectFail(t, observable.RequestHeader(r, "Accept", "application/json"))
+
+	direct := httptest.NewRequest(http.MethodPut, "/x", strings.NewReader(`{"id": 8}`))
+	testspy.ExpectFail(t, observable.RequestBodyJSON(direct, map[string]int{"id": 7}))
+
+	for _, tc := range []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.RequestQueryParam(r, "page", "3"), `expected query parameter page="3", got ["2" "3"]`},
+		{observable.RequestQueryParam(r, "limit", "1"), `expected query parameter limit="1", parameter missing from "dry=true&page=2&page=3"`},
+		{observable.RequestHeader(r, "accept", "text/plain"), `expected header Accept: "text/plain", header missing`},
+		{observable.RequestBodyJSON(httptest.NewRequest(http.MethodPut, "/x", strings.NewReader(`{"id": 8}`)), map[string]int{"id": 7}), `expected request body to match: expected $ to be {"id":7}, got {"id":8}`},
+	} {
+		if msg := tc.p.Message(); msg != tc.want {
+			t.Errorf("got message %q, want %q", msg, tc.want)
+		}
+	}
+}
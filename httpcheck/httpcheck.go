@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+// Package httpcheck tests [http.Handler]s in a single call: [Do] serves a request through an [httptest.ResponseRecorder] and asserts predicates over the response, dumping the full exchange when one fails.
+package httpcheck
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+)
+
+// ResponsePredicate builds a [observable.Predicate] over a response served by [Do]. The response body can be read in full; it is reset before each predicate is built.
+type ResponsePredicate func(resp *http.Response) observable.Predicate
+
+// Do serves req with handler and asserts every predicate in preds against the response, as [observable.Assert] does. When a predicate fails, its failure message is followed by a dump of the request and of the response. Do returns the response, whose body is unread.
+func Do(tb testing.TB, handler http.Handler, req *http.Request, preds ...ResponsePredicate) *http.Response {
+	tb.Helper()
+
+	reqDump, err := httputil.DumpRequest(req, true)
+	if err != nil {
+		reqDump = []byte(fmt.Sprintf("(could not dump request: %v)", err))
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	resp := rec.Result()
+
+	body, _ := io.ReadAll(resp.Body)
+	reset := func() { resp.Body = io.NopCloser(bytes.NewReader(body)) }
+
+	reset()
+	respDump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		respDump = []byte(fmt.Sprintf("(could not dump response: %v)", err))
+	}
+
+	for _, pred := range preds {
+		reset()
+		observable.Assertf(tb, pred(resp), "%P\n\nrequest:\n%s\n\nresponse:\n%s", indent(reqDump), indent(respDump))
+	}
+
+	reset()
+	return resp
+}
+
+// indent indents every line of a dump for readability within a failure message.
+func indent(dump []byte) string {
+	s := strings.TrimRight(strings.ReplaceAll(string(dump), "\r\n", "\n"), "\n")
+	return "  " + strings.ReplaceAll(s, "\n", "\n  ")
+}
+
+// Status returns a [ResponsePredicate] that is ok when the response status code is want.
+func Status(want int) ResponsePredicate {
+	return func(resp *http.Response) observable.Predicate {
+		return observable.NewPredicate(
+			func() bool { return resp.StatusCode == want },
+			func() string {
+				return fmt.Sprintf("expected status %d %s, got %s", want, http.StatusText(want), resp.Status)
+			},
+		)
+	}
+}
+
+// Header returns a [ResponsePredicate] that is ok when the response has the header key with the value want.
+func Header(key, want string) ResponsePredicate {
+	return func(resp *http.Response) observable.Predicate {
+		got := resp.Header.Values(key)
+		return observable.NewPredicate(
+			func() bool { return len(got) > 0 && got[0] == want },
+			func() string {
+				if len(got) == 0 {
+					return fmt.Sprintf("expected header %s: %q, header missing", http.CanonicalHeaderKey(key), want)
+				}
+				return fmt.Sprintf("expected header %s: %q, got %q", http.CanonicalHeaderKey(key), want, got)
+			},
+		)
+	}
+}
+
+// BodyContains returns a [ResponsePredicate] that is ok when the response body contains substr.
+func BodyContains(substr string) ResponsePredicate {
+	return func(resp *http.Response) observable.Predicate {
+		body, _ := io.ReadAll(resp.Body)
+		return observable.Named("body", observable.ContainsSubstring(string(body), substr))
+	}
+}
+
+// BodyJSON returns a [ResponsePredicate] that is ok when the response body is a JSON document equal to want, compared as by [observable.JSONPath] with the path "$".
+func BodyJSON(want any) ResponsePredicate {
+	return func(resp *http.Response) observable.Predicate {
+		body, _ := io.ReadAll(resp.Body)
+		return observable.JSONPath(body, "$", want)
+	}
+}
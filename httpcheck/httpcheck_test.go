@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package httpcheck_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/httpcheck"
+	"renorm.dev/observable/internal/testspy"
+)
+
+var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/orders/7" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, `{"id": 7, "status": "paid"}`)
+})
+
+func TestDo(t *testing.T) {
+	resp := httpcheck.Do(t, handler, httptest.NewRequest(http.MethodGet, "/orders/7", nil),
+		httpcheck.Status(http.StatusOK),
+		httpcheck.Header("content-type", "application/json"),
+		httpcheck.BodyContains(`"paid"`),
+		httpcheck.BodyJSON(map[string]any{"id": 7, "status": "paid"}),
+	)
+	if body, _ := io.ReadAll(resp.Body); !strings.Contains(string(body), "paid") {
+		t.Errorf("expected unread body, got %q", body)
+	}
+
+	spy := testspy.New(t)
+	httpcheck.Do(spy, handler, httptest.NewRequest(http.MethodPost, "/orders/8", strings.NewReader("hello")),
+		httpcheck.Status(http.StatusOK),
+		httpcheck.Header("Content-Type", "application/json"),
+	)
+	if len(spy.Messages) != 2 {
+		t.Fatalf("expected 2 failures, got %q", spy.Messages)
+	}
+
+	msg := spy.Messages[0]
+	for _, want := range []string{
+		"expected status 200 OK, got 404 Not Found\n\nrequest:\n  POST /orders/8 HTTP/1.1",
+		"\n  hello\n\nresponse:\n  HTTP/1.1 404 Not Found",
+		"\n  404 page not found",
+	} {
+		testspy.ExpectPass(t, observable.ContainsSubstring(msg, want))
+	}
+	testspy.ExpectPass(t, observable.HasPrefix(spy.Messages[1], `expected header Content-Type: "application/json", got ["text/plain; charset=utf-8"]`))
+}
@@ -5,6 +5,7 @@
 package testspy
 
 import (
+	"fmt"
 	"testing"
 
 	"renorm.dev/observable"
@@ -14,16 +15,38 @@ import (
 type SpyTB struct {
 	testing.TB
 	SpiedOnFailure bool
+	Messages       []string
+	Logs           []string
+	Attrs          map[string]string
 }
 
 // New creates a new SpyTB instance from a testing.TB.
 func New(t testing.TB) *SpyTB { return &SpyTB{TB: t} }
 
-// Error intercepts calls to the regular Error method to mark test failure.
-func (s *SpyTB) Error(...any) { s.SpiedOnFailure = true }
+// Error intercepts calls to the regular Error method to mark test failure and record the message.
+func (s *SpyTB) Error(args ...any) {
+	s.SpiedOnFailure = true
+	s.Messages = append(s.Messages, fmt.Sprint(args...))
+}
+
+// Errorf intercepts calls to the regular Errorf method to mark test failure and record the message.
+func (s *SpyTB) Errorf(format string, args ...any) {
+	s.SpiedOnFailure = true
+	s.Messages = append(s.Messages, fmt.Sprintf(format, args...))
+}
 
-// Errorf intercepts calls to the regular Errorf method to mark test failure.
-func (s *SpyTB) Errorf(string, ...any) { s.SpiedOnFailure = true }
+// Logf intercepts calls to the regular Logf method to record the message.
+func (s *SpyTB) Logf(format string, args ...any) {
+	s.Logs = append(s.Logs, fmt.Sprintf(format, args...))
+}
+
+// Attr intercepts calls to the regular Attr method to record test attributes.
+func (s *SpyTB) Attr(key, value string) {
+	if s.Attrs == nil {
+		s.Attrs = map[string]string{}
+	}
+	s.Attrs[key] = value
+}
 
 // Fail intercepts calls to the regular Fail method to mark test failure.
 func (s *SpyTB) Fail() { s.SpiedOnFailure = true }
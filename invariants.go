@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+var (
+	invariantsMu sync.Mutex
+	invariants   = map[string]int{}
+)
+
+// DeclareInvariants registers invariant names that are expected to be checked with [Invariant] during the test run. Declared invariants that never execute are reported by [UnexercisedInvariants].
+func DeclareInvariants(names ...string) {
+	invariantsMu.Lock()
+	defer invariantsMu.Unlock()
+
+	for _, name := range names {
+		if _, ok := invariants[name]; !ok {
+			invariants[name] = 0
+		}
+	}
+}
+
+// Invariant returns a copy of p that records an execution of the named invariant when it is evaluated; p's ID, name, description and messages are kept. Calling Invariant also declares name (see [DeclareInvariants]).
+func Invariant(name string, p Predicate) Predicate {
+	DeclareInvariants(name)
+
+	record := func() {
+		invariantsMu.Lock()
+		invariants[name]++
+		invariantsMu.Unlock()
+	}
+
+	r := p
+	r.negated = nil
+	r.ok = memo(func() bool { record(); return p.Ok() })
+	if p.okCtx != nil {
+		r.okCtx = func(ctx context.Context) bool { record(); return p.okCtx(ctx) }
+	}
+
+	return r
+}
+
+// InvariantCoverage returns the number of times each declared invariant has executed.
+func InvariantCoverage() map[string]int {
+	invariantsMu.Lock()
+	defer invariantsMu.Unlock()
+
+	coverage := make(map[string]int, len(invariants))
+	for name, n := range invariants {
+		coverage[name] = n
+	}
+
+	return coverage
+}
+
+// UnexercisedInvariants returns the sorted names of declared invariants that have never executed.
+func UnexercisedInvariants() []string {
+	var names []string
+	for name, n := range InvariantCoverage() {
+		if n == 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// WriteInvariantReport writes a summary of invariant executions to w, listing invariants that never ran first. It is intended to be called from TestMain after [testing.M.Run].
+func WriteInvariantReport(w io.Writer) error {
+	coverage := InvariantCoverage()
+
+	names := make([]string, 0, len(coverage))
+	for name := range coverage {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if dead := UnexercisedInvariants(); len(dead) > 0 {
+		if _, err := fmt.Fprintf(w, "invariants never executed: %v\n", dead); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range names {
+		if _, err := fmt.Fprintf(w, "%6d  %s\n", coverage[name], name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestInvariantCoverage(t *testing.T) {
+	observable.DeclareInvariants("test-never-runs")
+
+	total := 10
+	testspy.ExpectPass(t, observable.Invariant("test-total-non-negative", observable.That(total >= 0)))
+	testspy.ExpectFail(t, observable.Invariant("test-total-small", observable.That(total < 5)))
+
+	coverage := observable.InvariantCoverage()
+	if coverage["test-total-non-negative"] == 0 || coverage["test-total-small"] == 0 {
+		t.Errorf("expected evaluated invariants to be recorded, got %v", coverage)
+	}
+
+	testspy.ExpectPass(t, observable.Contains(observable.UnexercisedInvariants(), "test-never-runs"))
+	testspy.ExpectFail(t, observable.Contains(observable.UnexercisedInvariants(), "test-total-small"))
+
+	var sb strings.Builder
+	if err := observable.WriteInvariantReport(&sb); err != nil {
+		t.Fatal(err)
+	}
+	testspy.ExpectPass(t, observable.ContainsSubstring(sb.String(), "invariants never executed: [test-never-runs]"))
+}
+
+func TestInvariantKeepsPredicate(t *testing.T) {
+	p := observable.Invariant("test-keeps-predicate", observable.Named("small", observable.Equal(10, 5).WithID("INV-1")))
+
+	testspy.ExpectFail(t, p)
+	testspy.ExpectPass(t, observable.Equal(p.ID(), "INV-1"))
+	testspy.ExpectPass(t, observable.Equal(p.Describe(), "small"))
+	testspy.ExpectPass(t, observable.Equal(p.Message(), "[INV-1] small: expected 5, got 10"))
+	testspy.ExpectPass(t, observable.Equal(observable.Not(observable.Invariant("test-keeps-predicate", observable.Equal(1, 1))).Message(), "expected values to differ, both 1"))
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// JSONPath returns a [Predicate] that is ok when the value at path in the JSON document doc equals want. path is a dotted expression with array indices, optionally rooted at "$", e.g. "items[2].name" or `$.labels["app.kubernetes.io/name"]`. want is compared after a round trip through JSON, so 3 matches 3.0 in doc and a struct matches the object it marshals to. On failure the value found is reported, or the point at which navigation failed. JSONPath **panics** if path is malformed.
+func JSONPath(doc []byte, path string, want any) Predicate {
+	steps := parseJSONPath(path)
+
+	var (
+		once     sync.Once
+		got      any
+		reached  string
+		problem  string
+		expected any
+	)
+
+	eval := func() {
+		once.Do(func() {
+			expected = stableValue(want)
+
+			dec := json.NewDecoder(bytes.NewReader(doc))
+			dec.UseNumber()
+			if err := dec.Decode(&got); err != nil {
+				problem = sprintf("invalid JSON: %v", err)
+				return
+			}
+			got = normalizeNumbers(got)
+
+			reached = "$"
+			for _, s := range steps {
+				if got, problem = s.apply(got); problem != "" {
+					return
+				}
+				reached += s.String()
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return problem == "" && reflect.DeepEqual(got, expected) },
+		msg: func() string {
+			eval()
+			if problem != "" {
+				return sprintf("expected %s to be %s, %s at %s", path, compactJSON(expected), problem, reached)
+			}
+			return sprintf("expected %s to be %s, got %s", path, compactJSON(expected), compactJSON(got))
+		},
+		desc: func() string { return fmt.Sprintf("%s == %s", path, compactJSON(stableValue(want))) },
+	}
+}
+
+// jsonStep is one step of a path: an object key, or an array index if key is nil.
+type jsonStep struct {
+	key   *string
+	index int
+}
+
+func (s jsonStep) String() string {
+	switch {
+	case s.key == nil:
+		return fmt.Sprintf("[%d]", s.index)
+	case strings.ContainsAny(*s.key, ".[]\"") || *s.key == "":
+		return fmt.Sprintf("[%q]", *s.key)
+	default:
+		return "." + *s.key
+	}
+}
+
+// apply returns the value reached by taking s from v, or a description of why s cannot be taken.
+func (s jsonStep) apply(v any) (any, string) {
+	if s.key != nil {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, sprintf("found %s instead of an object", jsonKind(v))
+		}
+		e, ok := obj[*s.key]
+		if !ok {
+			keys := make([]string, 0, len(obj))
+			for k := range obj {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return nil, sprintf("key %q not found among %q", *s.key, keys)
+		}
+		return e, ""
+	}
+
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, sprintf("found %s instead of an array", jsonKind(v))
+	}
+	if s.index < 0 || s.index >= len(arr) {
+		return nil, sprintf("index %d out of range for array of %d elements", s.index, len(arr))
+	}
+	return arr[s.index], ""
+}
+
+// parseJSONPath splits path into steps, panicking if it is malformed.
+func parseJSONPath(path string) []jsonStep {
+	bad := func(format string, args ...any) {
+		panic(fmt.Sprintf("JSONPath: invalid path %q: %s", path, fmt.Sprintf(format, args...)))
+	}
+
+	s := strings.TrimPrefix(path, "$")
+	var steps []jsonStep
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '.':
+			i++
+			j := i
+			for j < len(s) && s[j] != '.' && s[j] != '[' {
+				j++
+			}
+			if j == i {
+				bad("empty key at offset %d", i)
+			}
+			if k := strings.IndexAny(s[i:j], `]"`); k >= 0 {
+				bad("unexpected %q at offset %d", s[i+k], i+k)
+			}
+			key := s[i:j]
+			steps = append(steps, jsonStep{key: &key})
+			i = j
+		case '[':
+			j := i + 1
+			if j < len(s) && s[j] == '"' {
+				// A quoted key may contain "]", so scan to its closing quote first.
+				for j++; j < len(s) && s[j] != '"'; j++ {
+					if s[j] == '\\' {
+						j++
+					}
+				}
+				j++
+			}
+			for j < len(s) && s[j] != ']' {
+				j++
+			}
+			if j >= len(s) {
+				bad("unterminated [ at offset %d", i)
+			}
+
+			inner := s[i+1 : j]
+			if strings.HasPrefix(inner, `"`) {
+				key, err := strconv.Unquote(inner)
+				if err != nil {
+					bad("invalid key %s", inner)
+				}
+				steps = append(steps, jsonStep{key: &key})
+			} else {
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					bad("invalid index %q", inner)
+				}
+				steps = append(steps, jsonStep{index: n})
+			}
+			i = j + 1
+		default:
+			if i > 0 {
+				bad("unexpected %q at offset %d", s[i], i)
+			}
+			// A path may start with a bare key, e.g. "items[2]".
+			s = "." + s
+		}
+	}
+
+	return steps
+}
+
+// jsonKind names the JSON type of a decoded value.
+func jsonKind(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return sprintf("null")
+	case map[string]any:
+		return sprintf("an object")
+	case []any:
+		return sprintf("an array")
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case bool:
+		return fmt.Sprintf("boolean %v", v)
+	default:
+		return fmt.Sprintf("number %v", v)
+	}
+}
+
+// compactJSON renders a decoded value as JSON.
+func compactJSON(v any) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
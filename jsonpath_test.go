@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestJSONPath(t *testing.T) {
+	doc := []byte(`{
+		"items": [{"name": "a"}, {"name": "b"}, {"name": "c", "price": 3.0}],
+		"labels": {"app.kubernetes.io/name": "web"},
+		"ok": true
+	}`)
+
+	testspy.ExpectPass(t, observable.JSONPath(doc, "items[2].name", "c"))
+	testspy.ExpectPass(t, observable.JSONPath(doc, "$.items[2].price", 3))
+	testspy.ExpectPass(t, observable.JSONPath(doc, `$.labels["app.kubernetes.io/name"]`, "web"))
+	testspy.ExpectPass(t, observable.JSONPath(doc, "ok", true))
+	testspy.ExpectPass(t, observable.JSONPath(doc, "items[0]", map[string]string{"name": "a"}))
+	testspy.ExpectPass(t, observable.JSONPath(doc, "$", map[string]any{
+		"items":  []map[string]any{{"name": "a"}, {"name": "b"}, {"name": "c", "price": 3}},
+		"labels": map[string]string{"app.kubernetes.io/name": "web"},
+		"ok":     true,
+	}))
+	testspy.ExpectFail(t, observable.JSONPath(doc, "items[2].name", "b"))
+	testspy.ExpectFail(t, observable.JSONPath([]byte("{"), "ok", true))
+
+	for _, tc := range []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.JSONPath(doc, "items[2].name", "b"), `expected items[2].name to be "b", got "c"`},
+		{observable.JSONPath(doc, "items[5].name", "b"), `expected items[5].name to be "b", index 5 out of range for array of 3 elements at $.items`},
+		{observable.JSONPath(doc, "items[1].size", 1), `expected items[1].size to be 1, key "size" not found among ["name"] at $.items[1]`},
+		{observable.JSONPath(doc, "ok.value", 1), `expected ok.value to be 1, found boolean true instead of an object at $.ok`},
+		{observable.JSONPath(doc, "labels[0]", 1), `expected labels[0] to be 1, found an object instead of an array at $.labels`},
+	} {
+		if msg := tc.p.Message(); msg != tc.want {
+			t.Errorf("got message %q, want %q", msg, tc.want)
+		}
+	}
+
+	for _, path := range []string{"items[", "items[x]", "items..name", `labels["a`, "items]"} {
+		testspy.ExpectPass(t, observable.Panics(func() { observable.JSONPath(doc, path, nil) }))
+	}
+}
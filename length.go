@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// previewBytes caps the rendering of contents shown in length failure messages.
+const previewBytes = 80
+
+// Len returns a [Predicate] that is ok when len(v) == want. v may be anything the len builtin accepts: a string, slice, array, pointer to array, map or channel; Len **panics** otherwise. The length is read once, on first evaluation. Prefer [Length], [StringLength], [MapLength] or [ChanLength] when the type is known statically; they avoid reflection.
+func Len(v any, want int) Predicate {
+	rv := reflect.ValueOf(v)
+
+	format := "expected length %d, got %d"
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array:
+	case reflect.Ptr:
+		if rv.Type().Elem().Kind() != reflect.Array {
+			panic(fmt.Sprintf("Len: unsupported type %T", v))
+		}
+	case reflect.Map:
+		format = "expected map size %d, got %d"
+	case reflect.Chan:
+		format = "expected channel buffer length %d, got %d"
+	default:
+		panic(fmt.Sprintf("Len: unsupported type %T", v))
+	}
+
+	var (
+		once sync.Once
+		n    int
+	)
+
+	eval := func() { once.Do(func() { n = rv.Len() }) }
+
+	return Predicate{
+		ok:   func() bool { eval(); return n == want },
+		msg:  func() string { eval(); return sprintf(format, want, n) },
+		desc: func() string { return fmt.Sprintf("len == %d", want) },
+	}
+}
+
+// LengthAtLeast returns a [Predicate] that is ok when len(v) >= min. v must be a string, slice, array or map; LengthAtLeast **panics** otherwise, and also when min is negative. On failure the actual length and a preview of the contents are reported.
+func LengthAtLeast(v any, min int) Predicate {
+	return lengthWithin("LengthAtLeast", v, min, math.MaxInt, fmt.Sprintf("len >= %d", min))
+}
+
+// LengthAtMost returns a [Predicate] that is ok when len(v) <= max. v must be a string, slice, array or map; LengthAtMost **panics** otherwise, and also when max is negative. On failure the actual length and a preview of the contents are reported.
+func LengthAtMost(v any, max int) Predicate {
+	return lengthWithin("LengthAtMost", v, 0, max, fmt.Sprintf("len <= %d", max))
+}
+
+// LengthBetween returns a [Predicate] that is ok when min <= len(v) <= max. v must be a string, slice, array or map; LengthBetween **panics** otherwise, and also when min is negative or greater than max.
+func LengthBetween(v any, min, max int) Predicate {
+	if min > max {
+		panic(fmt.Sprintf("LengthBetween: min %d is greater than max %d", min, max))
+	}
+
+	return lengthWithin("LengthBetween", v, min, max, fmt.Sprintf("%d <= len <= %d", min, max))
+}
+
+// lengthWithin implements the length bound predicates, named name in panic messages.
+func lengthWithin(name string, v any, min, max int, desc string) Predicate {
+	for _, bound := range []int{min, max} {
+		if bound < 0 {
+			panic(fmt.Sprintf("%s: negative length bound %d", name, bound))
+		}
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		panic(fmt.Sprintf("%s: unsupported type %T, want a string, slice, array or map", name, v))
+	}
+
+	var (
+		once sync.Once
+		n    int
+	)
+
+	eval := func() { once.Do(func() { n = rv.Len() }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return n >= min && n <= max },
+		msg: func() string {
+			eval()
+			return sprintf("expected %s, got %d: %s", desc, n, preview(v))
+		},
+		desc: func() string { return desc },
+	}
+}
+
+// preview renders v with %v, truncated to [previewBytes].
+func preview(v any) string {
+	s := fmt.Sprintf("%v", v)
+	if _, ok := v.(string); ok {
+		s = fmt.Sprintf("%q", v)
+	}
+	if len(s) <= previewBytes {
+		return s
+	}
+	return truncateUTF8(s, previewBytes) + "…"
+}
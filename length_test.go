@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestLengthBounds(t *testing.T) {
+	s := []int{1, 2, 3}
+	m := map[string]int{"a": 1}
+
+	testspy.ExpectPass(t, observable.LengthAtLeast(s, 3))
+	testspy.ExpectFail(t, observable.LengthAtLeast(s, 4))
+	testspy.ExpectPass(t, observable.LengthAtMost("abc", 3))
+	testspy.ExpectFail(t, observable.LengthAtMost("abcd", 3))
+	testspy.ExpectPass(t, observable.LengthBetween(m, 1, 2))
+	testspy.ExpectFail(t, observable.LengthBetween(m, 2, 3))
+	testspy.ExpectPass(t, observable.LengthBetween([2]int{}, 2, 2))
+
+	for _, tc := range []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.LengthAtLeast(s, 4), "expected len >= 4, got 3: [1 2 3]"},
+		{observable.LengthAtMost("abcd", 3), `expected len <= 3, got 4: "abcd"`},
+		{observable.LengthBetween(m, 2, 3), "expected 2 <= len <= 3, got 1: map[a:1]"},
+		{observable.LengthAtMost(strings.Repeat("x", 100), 10), `expected len <= 10, got 100: "` + strings.Repeat("x", 79) + "…"},
+	} {
+		if msg := tc.p.Message(); msg != tc.want {
+			t.Errorf("got message %q, want %q", msg, tc.want)
+		}
+	}
+}
+
+func TestLengthBoundsPanics(t *testing.T) {
+	for name, tc := range map[string]struct {
+		f    func()
+		want string
+	}{
+		"unsupported":      {func() { observable.LengthAtLeast(42, 1) }, "LengthAtLeast: unsupported type int, want a string, slice, array or map"},
+		"inverted":         {func() { observable.LengthBetween("", 3, 1) }, "LengthBetween: min 3 is greater than max 1"},
+		"negative max":     {func() { observable.LengthAtMost("", -1) }, "LengthAtMost: negative length bound -1"},
+		"negative min":     {func() { observable.LengthAtLeast("", -1) }, "LengthAtLeast: negative length bound -1"},
+		"negative between": {func() { observable.LengthBetween("", -5, -1) }, "LengthBetween: negative length bound -5"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tc.want {
+					t.Errorf("expected panic %q, got %v", tc.want, r)
+				}
+			}()
+			tc.f()
+		})
+	}
+}
+
+func TestLen(t *testing.T) {
+	c := make(chan int, 3)
+	c <- 1
+
+	testspy.ExpectPass(t, observable.Len("abc", 3))
+	testspy.ExpectPass(t, observable.Len([]int{1, 2}, 2))
+	testspy.ExpectPass(t, observable.Len([4]int{}, 4))
+	testspy.ExpectPass(t, observable.Len(&[4]int{}, 4))
+	testspy.ExpectPass(t, observable.Len(map[int]int{1: 1}, 1))
+	testspy.ExpectPass(t, observable.Len(c, 1))
+	testspy.ExpectPass(t, observable.Len([]int(nil), 0))
+	testspy.ExpectFail(t, observable.Len("abc", 2))
+	testspy.ExpectFail(t, observable.Len(c, 0))
+
+	for _, tc := range []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.Len("abc", 2), "expected length 2, got 3"},
+		{observable.Len(map[int]int{}, 1), "expected map size 1, got 0"},
+		{observable.Len(c, 2), "expected channel buffer length 2, got 1"},
+	} {
+		if msg := tc.p.Message(); msg != tc.want {
+			t.Errorf("got message %q, want %q", msg, tc.want)
+		}
+	}
+
+	testspy.ExpectPass(t, observable.Panics(func() { observable.Len(42, 0) }))
+	testspy.ExpectPass(t, observable.Panics(func() { observable.Len(new(int), 0) }))
+}
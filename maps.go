@@ -5,57 +5,200 @@ package observable
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 )
 
 // ContainsKey returns a [Predicate] that is ok when key exists in map m.
 func ContainsKey[K comparable, V any](m map[K]V, key K) Predicate {
 	return Predicate{
-		ok:  func() bool { _, ok := m[key]; return ok },
-		msg: func() string { return fmt.Sprintf("expected map to contain key %v", key) },
+		ok:   memo(func() bool { _, ok := m[key]; return ok }),
+		msg:  func() string { return sprintf("expected map to contain key %v", key) },
+		neg:  func() string { return sprintf("expected map not to contain key %v", key) },
+		desc: func() string { return fmt.Sprintf("has key %v", key) },
 	}
 }
 
 // ContainsValue returns a [Predicate] that is ok when val appears as a value in map m.
 func ContainsValue[K comparable, V comparable](m map[K]V, val V) Predicate {
 	return Predicate{
-		ok: func() bool {
+		ok: memo(func() bool {
 			for _, v := range m {
 				if v == val {
 					return true
 				}
 			}
 			return false
-		},
-		msg: func() string { return fmt.Sprintf("expected map to contain value %v", val) },
+		}),
+		msg:  func() string { return sprintf("expected map %s to contain value %v", renderMap(m), val) },
+		desc: func() string { return fmt.Sprintf("has value %v", val) },
 	}
 }
 
-// MapEqual returns a [Predicate] that is ok when two maps are deeply equal (reflect.DeepEqual).
+// MapEqual returns a [Predicate] that is ok when two maps are deeply equal (reflect.DeepEqual). On failure the differing entries are listed in sorted key order, followed by both maps.
 func MapEqual[K comparable, V any](got, want map[K]V) Predicate {
+	return mapEqual(got, want, nil)
+}
+
+// MapEqualFunc returns a [Predicate] that is ok when got and want have the same keys and eq reports true for the values of each key, for value types that need an approximate or domain-specific equality. As for [MapEqual], a nil map does not equal an empty one. MapEqualFunc **panics** if eq is nil.
+func MapEqualFunc[K comparable, V any](got, want map[K]V, eq func(got, want V) bool) Predicate {
+	if eq == nil {
+		panic("observable: MapEqualFunc: nil eq")
+	}
+
+	return mapEqual(got, want, eq)
+}
+
+// mapEqual implements [MapEqual] and [MapEqualFunc]; a nil eq compares values with [reflect.DeepEqual].
+func mapEqual[K comparable, V any](got, want map[K]V, eq func(got, want V) bool) Predicate {
 	var (
-		once  sync.Once
-		match bool
+		once     sync.Once
+		problems []string
 	)
 
-	check := func() { once.Do(func() { match = reflect.DeepEqual(got, want) }) }
+	check := func() {
+		once.Do(func() {
+			if eq == nil {
+				if reflect.DeepEqual(got, want) {
+					return
+				}
+				eq = func(got, want V) bool { return reflect.DeepEqual(got, want) }
+			}
+			if (got == nil) != (want == nil) {
+				problems = append(problems, sprintf("nil map: want %v, got %v", want == nil, got == nil))
+			}
+			for _, k := range sortedKeys(want) {
+				g, ok := got[k]
+				switch {
+				case !ok:
+					problems = append(problems, sprintf("missing key %#v", k))
+				case !eq(g, want[k]):
+					problems = append(problems, sprintf("key %#v: expected %#v, got %#v", k, want[k], g))
+				}
+			}
+			for _, k := range sortedKeys(got) {
+				if _, ok := want[k]; !ok {
+					problems = append(problems, sprintf("extra key %#v", k))
+				}
+			}
+		})
+	}
 
 	return Predicate{
 		ok: func() bool {
 			check()
-			return match
+			return len(problems) == 0
 		},
 		msg: func() string {
 			check()
-			return fmt.Sprintf("expected maps to be equal\nwant: %#v\ngot:  %#v", want, got)
+			return sprintf("expected maps to be equal:\n  %s\nwant: %s\ngot:  %s", strings.Join(problems, "\n  "), renderMap(want), renderMap(got))
 		},
+		desc:   func() string { return "maps equal" },
+		values: func() (any, any) { return got, want },
 	}
 }
 
 // MapLength returns a [Predicate] that is ok when len(m) == want.
 func MapLength[K comparable, V any](m map[K]V, want int) Predicate {
 	return Predicate{
-		ok:  func() bool { return len(m) == want },
-		msg: func() string { return fmt.Sprintf("expected map size %d, got %d", want, len(m)) },
+		ok:   memo(func() bool { return len(m) == want }),
+		msg:  func() string { return sprintf("expected map size %d, got %d", want, len(m)) },
+		desc: func() string { return fmt.Sprintf("len == %d", want) },
+	}
+}
+
+// MapSubset returns a [Predicate] that is ok when every key/value pair in want is present in got, with values compared by [reflect.DeepEqual]. Extra keys in got are ignored. On failure the missing keys and mismatched values are listed.
+func MapSubset[K comparable, V any](got, want map[K]V) Predicate {
+	var (
+		once     sync.Once
+		problems []string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			for _, k := range sortedKeys(want) {
+				g, ok := got[k]
+				switch {
+				case !ok:
+					problems = append(problems, sprintf("missing key %#v", k))
+				case !reflect.DeepEqual(g, want[k]):
+					problems = append(problems, sprintf("key %#v: expected %#v, got %#v", k, want[k], g))
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return len(problems) == 0 },
+		msg: func() string {
+			eval()
+			return sprintf("expected map to contain entries:\n  %s", strings.Join(problems, "\n  "))
+		},
+		desc: func() string { return fmt.Sprintf("contains %d entries", len(want)) },
+	}
+}
+
+// KeysMatch returns a [Predicate] that is ok when the keys of m are exactly wantKeys, in any order. On failure the missing and extra keys are listed as for [ElementsMatch].
+func KeysMatch[K comparable, V any](m map[K]V, wantKeys []K) Predicate {
+	p := ElementsMatch(sortedKeys(m), wantKeys)
+	msg := p.msg
+	p.msg = func() string { return sprintf("expected map keys %v", wantKeys) + "\n" + msg() }
+	p.desc = func() string { return fmt.Sprintf("keys match %v", wantKeys) }
+	return p
+}
+
+// ValuesMatch returns a [Predicate] that is ok when the values of m are the multiset wantValues, in any order. On failure the missing and extra values, and values occurring a different number of times, are listed as for [ElementsMatch].
+func ValuesMatch[K comparable, V comparable](m map[K]V, wantValues []V) Predicate {
+	values := make([]V, 0, len(m))
+	for _, k := range sortedKeys(m) {
+		values = append(values, m[k])
+	}
+
+	p := ElementsMatch(values, wantValues)
+	msg := p.msg
+	p.msg = func() string { return sprintf("expected map values %v", wantValues) + "\n" + msg() }
+	p.desc = func() string { return fmt.Sprintf("values match %v", wantValues) }
+	return p
+}
+
+// sortedKeys returns the keys of m in the order fmt prints map keys: numbers by value, strings lexically and false before true, so that listed keys line up with the rendered maps. Other key types are ordered by their %#v representation.
+func sortedKeys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keyLess(reflect.ValueOf(keys[i]), reflect.ValueOf(keys[j])) })
+
+	return keys
+}
+
+// keyLess reports whether the map key a sorts before b.
+func keyLess(a, b reflect.Value) bool {
+	if a.IsValid() && b.IsValid() && a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Bool:
+			return !a.Bool() && b.Bool()
+		}
+	}
+
+	return fmt.Sprintf("%#v", a) < fmt.Sprintf("%#v", b)
+}
+
+// renderMap formats m like %#v, which prints entries in key order.
+func renderMap[K comparable, V any](m map[K]V) string {
+	if m == nil {
+		return fmt.Sprintf("%T(nil)", m)
 	}
+
+	return fmt.Sprintf("%#v", m)
 }
@@ -3,6 +3,7 @@
 package observable_test
 
 import (
+	"strings"
 	"testing"
 
 	"renorm.dev/observable"
@@ -31,3 +32,89 @@ func TestMapAsserts(t *testing.T) {
 	newmap["c"] = 3
 	testspy.ExpectFail(t, observable.MapEqual(m, newmap))
 }
+
+func TestMapEqualFunc(t *testing.T) {
+	fold := func(got, want string) bool { return strings.EqualFold(got, want) }
+
+	testspy.ExpectPass(t, observable.MapEqualFunc(map[int]string{1: "Go"}, map[int]string{1: "go"}, fold))
+	testspy.ExpectFail(t, observable.MapEqualFunc(map[int]string{1: "Go"}, map[int]string{1: "rust"}, fold))
+	testspy.ExpectFail(t, observable.MapEqualFunc(map[int]string{1: "Go"}, map[int]string{2: "go"}, fold))
+	testspy.ExpectFail(t, observable.MapEqualFunc(nil, map[int]string{}, fold))
+
+	msg := observable.MapEqualFunc(map[int]string{1: "Go"}, map[int]string{1: "rust"}, fold).Message()
+	testspy.ExpectPass(t, observable.ContainsSubstring(msg, `key 1: expected "rust", got "Go"`))
+
+	testspy.ExpectPass(t, observable.Panics(func() { observable.MapEqualFunc(map[int]int{}, map[int]int{}, nil) }))
+}
+
+func TestMapSubset(t *testing.T) {
+	headers := map[string]string{
+		"Content-Type": "application/json",
+		"X-Request-Id": "abc",
+		"Server":       "test",
+	}
+
+	testspy.ExpectPass(t, observable.MapSubset(headers, map[string]string{"Content-Type": "application/json"}))
+	testspy.ExpectPass(t, observable.MapSubset(headers, map[string]string{}))
+	testspy.ExpectFail(t, observable.MapSubset(headers, map[string]string{"Content-Type": "text/plain"}))
+	testspy.ExpectFail(t, observable.MapSubset(headers, map[string]string{"Accept": "*/*"}))
+
+	msg := observable.MapSubset(headers, map[string]string{"Accept": "*/*", "Server": "prod"}).Message()
+	if !strings.Contains(msg, `missing key "Accept"`) || !strings.Contains(msg, `key "Server": expected "prod", got "test"`) {
+		t.Errorf("expected missing and mismatched entries in message, got %q", msg)
+	}
+}
+
+func TestMapMessagesSortedNumerically(t *testing.T) {
+	got := map[int]string{2: "b", 10: "x", 100: "y"}
+	want := map[int]string{2: "b", 9: "z", 10: "j"}
+
+	wantMsg := `expected maps to be equal:
+  missing key 9
+  key 10: expected "j", got "x"
+  extra key 100
+want: map[int]string{2:"b", 9:"z", 10:"j"}
+got:  map[int]string{2:"b", 10:"x", 100:"y"}`
+	if msg := observable.MapEqual(got, want).Message(); msg != wantMsg {
+		t.Errorf("got message\n%s\nwant\n%s", msg, wantMsg)
+	}
+}
+
+func TestMapMessagesSorted(t *testing.T) {
+	got := map[string]int{"b": 2, "d": 1, "a": 3, "e": 4}
+	want := map[string]int{"b": 2, "d": 1, "a": 1, "c": 5}
+
+	wantMsg := `expected maps to be equal:
+  key "a": expected 1, got 3
+  missing key "c"
+  extra key "e"
+want: map[string]int{"a":1, "b":2, "c":5, "d":1}
+got:  map[string]int{"a":3, "b":2, "d":1, "e":4}`
+	for i := 0; i < 5; i++ {
+		if msg := observable.MapEqual(got, want).Message(); msg != wantMsg {
+			t.Fatalf("got message\n%s\nwant\n%s", msg, wantMsg)
+		}
+	}
+
+	if msg := observable.ContainsValue(map[string]int{"z": 26, "a": 1}, 3).Message(); msg != `expected map map[string]int{"a":1, "z":26} to contain value 3` {
+		t.Errorf("unexpected message: %q", msg)
+	}
+}
+
+func TestKeysValuesMatch(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 1}
+
+	testspy.ExpectPass(t, observable.KeysMatch(m, []string{"c", "a", "b"}))
+	testspy.ExpectFail(t, observable.KeysMatch(m, []string{"a", "b", "d"}))
+	testspy.ExpectPass(t, observable.ValuesMatch(m, []int{1, 2, 1}))
+	testspy.ExpectFail(t, observable.ValuesMatch(m, []int{1, 2}))
+
+	want := "expected map keys [a b d]\nexpected slices to contain the same elements (got 3, want 3)\nmissing from got: [d]\nextra in got:     [c]"
+	if msg := observable.KeysMatch(m, []string{"a", "b", "d"}).Message(); msg != want {
+		t.Errorf("got message\n%s\nwant\n%s", msg, want)
+	}
+	want = "expected map values [1 2]\nexpected slices to contain the same elements (got 3, want 2)\ncount mismatch:   1 (got 2, want 1)"
+	if msg := observable.ValuesMatch(m, []int{1, 2}).Message(); msg != want {
+		t.Errorf("got message\n%s\nwant\n%s", msg, want)
+	}
+}
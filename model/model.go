@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+// Package model implements model-based testing on top of [observable.Predicate]. A [Spec] describes a simplified model of a stateful system together with the commands that can be applied to both; [Check] runs random command sequences against the real system, verifies each command's postcondition against the model, and reports a minimized failing sequence.
+package model
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"renorm.dev/observable"
+)
+
+// Command is an operation that can be applied to the model M and to the system under test S.
+type Command[M, S any] interface {
+	// String describes the command, including its arguments, in failure reports.
+	String() string
+	// Pre reports whether the command may run in model state m.
+	Pre(m M) bool
+	// Run applies the command to s and returns its postcondition, checked against the model state m from before the command.
+	Run(s S, m M) observable.Predicate
+	// Next returns the model state after the command.
+	Next(m M) M
+}
+
+// Spec describes a model-based test.
+type Spec[M, S any] struct {
+	// Init returns the initial model state.
+	Init func() M
+	// New returns a fresh system under test for each sequence.
+	New func() S
+	// Cleanup, if non-nil, releases a system returned by New.
+	Cleanup func(S)
+	// Generate returns a random command for model state m. Commands whose precondition does not hold are discarded.
+	Generate func(r *rand.Rand, m M) Command[M, S]
+
+	// Runs is the number of sequences to try. Defaults to 100.
+	Runs int
+	// MaxSteps is the length of each generated sequence. Defaults to 50.
+	MaxSteps int
+	// Seed seeds command generation. Zero uses the current time.
+	Seed int64
+}
+
+// Check returns an [observable.Predicate] that is ok when every generated command sequence satisfies all postconditions. On failure the sequence is minimized by removing commands for as long as it keeps failing, and the minimized sequence is reported with the seed.
+func Check[M, S any](spec Spec[M, S]) observable.Predicate {
+	var (
+		once    sync.Once
+		seed    = spec.Seed
+		failing []Command[M, S]
+		message string
+	)
+
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	eval := func() {
+		once.Do(func() {
+			r := rand.New(rand.NewSource(seed))
+			for i := 0; i < orDefault(spec.Runs, 100); i++ {
+				seq := spec.generate(r)
+				if step, _ := spec.execute(seq); step >= 0 {
+					failing = spec.shrink(seq[:step+1])
+					_, message = spec.execute(failing)
+					return
+				}
+			}
+		})
+	}
+
+	return observable.NewPredicate(func() bool { eval(); return failing == nil }, func() string {
+		eval()
+		if failing == nil {
+			return fmt.Sprintf("all %d command sequences passed (seed %d)", orDefault(spec.Runs, 100), seed)
+		}
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "model check failed (seed %d), minimized sequence:", seed)
+		for i, c := range failing {
+			fmt.Fprintf(&sb, "\n  %d. %s", i+1, c)
+		}
+		fmt.Fprintf(&sb, "\nstep %d: %s", len(failing), message)
+
+		return sb.String()
+	})
+}
+
+// generate returns a random sequence of commands whose preconditions hold when applied in order.
+func (spec Spec[M, S]) generate(r *rand.Rand) []Command[M, S] {
+	steps := orDefault(spec.MaxSteps, 50)
+	m := spec.Init()
+
+	var seq []Command[M, S]
+	for attempts := 0; len(seq) < steps && attempts < 10*steps; attempts++ {
+		c := spec.Generate(r, m)
+		if !c.Pre(m) {
+			continue
+		}
+		seq = append(seq, c)
+		m = c.Next(m)
+	}
+
+	return seq
+}
+
+// execute runs seq against a fresh system. It returns the index of the first command whose postcondition failed together with the failure message, or -1 if the sequence passed or is invalid because a precondition does not hold.
+func (spec Spec[M, S]) execute(seq []Command[M, S]) (int, string) {
+	m, s := spec.Init(), spec.New()
+	if spec.Cleanup != nil {
+		defer spec.Cleanup(s)
+	}
+
+	for i, c := range seq {
+		if !c.Pre(m) {
+			return -1, ""
+		}
+		if p := c.Run(s, m); !p.Ok() {
+			return i, p.Message()
+		}
+		m = c.Next(m)
+	}
+
+	return -1, ""
+}
+
+// shrink removes commands from the failing sequence seq for as long as it keeps failing.
+func (spec Spec[M, S]) shrink(seq []Command[M, S]) []Command[M, S] {
+	for changed := true; changed; {
+		changed = false
+		for i := 0; i < len(seq); i++ {
+			candidate := append(append([]Command[M, S](nil), seq[:i]...), seq[i+1:]...)
+			if step, _ := spec.execute(candidate); step >= 0 {
+				seq = candidate[:step+1]
+				changed = true
+				i--
+			}
+		}
+	}
+
+	return seq
+}
+
+func orDefault(n, def int) int {
+	if n <= 0 {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package model_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+	"renorm.dev/observable/model"
+)
+
+// cache is a tiny key/value store. When buggy is set it forgets the oldest key once it holds more than two.
+type cache struct {
+	buggy bool
+	keys  []string
+	vals  map[string]int
+}
+
+func (c *cache) Put(k string, v int) {
+	if _, ok := c.vals[k]; !ok {
+		c.keys = append(c.keys, k)
+	}
+	c.vals[k] = v
+	if c.buggy && len(c.keys) > 2 {
+		delete(c.vals, c.keys[0])
+		c.keys = c.keys[1:]
+	}
+}
+
+func (c *cache) Get(k string) (int, bool) { v, ok := c.vals[k]; return v, ok }
+
+type state map[string]int
+
+type put struct {
+	k string
+	v int
+}
+
+func (p put) String() string { return fmt.Sprintf("Put(%q, %d)", p.k, p.v) }
+func (put) Pre(state) bool   { return true }
+func (p put) Next(m state) state {
+	n := state{p.k: p.v}
+	for k, v := range m {
+		if k != p.k {
+			n[k] = v
+		}
+	}
+	return n
+}
+func (p put) Run(c *cache, _ state) observable.Predicate { c.Put(p.k, p.v); return observable.True() }
+
+type get struct{ k string }
+
+func (g get) String() string   { return fmt.Sprintf("Get(%q)", g.k) }
+func (get) Pre(state) bool     { return true }
+func (get) Next(m state) state { return m }
+func (g get) Run(c *cache, m state) observable.Predicate {
+	v, ok := c.Get(g.k)
+	want, wantOK := m[g.k]
+	return observable.All(observable.Equal(ok, wantOK), observable.Equal(v, want))
+}
+
+func spec(buggy bool) model.Spec[state, *cache] {
+	keys := []string{"a", "b", "c", "d"}
+
+	return model.Spec[state, *cache]{
+		Init: func() state { return state{} },
+		New:  func() *cache { return &cache{buggy: buggy, vals: map[string]int{}} },
+		Generate: func(r *rand.Rand, _ state) model.Command[state, *cache] {
+			k := keys[r.Intn(len(keys))]
+			if r.Intn(2) == 0 {
+				return put{k, r.Intn(10)}
+			}
+			return get{k}
+		},
+		Seed: 1,
+	}
+}
+
+func TestCheck(t *testing.T) {
+	testspy.ExpectPass(t, model.Check(spec(false)))
+	testspy.ExpectFail(t, model.Check(spec(true)))
+
+	msg := model.Check(spec(true)).Message()
+	if !strings.Contains(msg, "minimized sequence") || strings.Count(msg, "Put(") != 3 || strings.Count(msg, "Get(") != 1 {
+		t.Errorf("expected a minimized sequence of three puts and a get, got:\n%s", msg)
+	}
+}
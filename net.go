@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DialInterval is the time [DialSucceeds] and [PortListening] wait between connection attempts.
+var DialInterval = 50 * time.Millisecond
+
+// PortTimeout is how long [PortListening] keeps trying to connect.
+var PortTimeout = 5 * time.Second
+
+// DialSucceeds returns a [Predicate] that is ok when a connection to addr on the named network (see [net.Dial]) can be established within timeout. Connections are attempted every [DialInterval] until one succeeds, as with [Eventually], so that servers started in the background have time to come up; successful connections are closed immediately. On failure the number of attempts and the last error are reported.
+func DialSucceeds(network, addr string, timeout time.Duration) Predicate {
+	p := Eventually(timeout, DialInterval, func() Predicate { return dialOnce(network, addr) })
+	p.desc = func() string { return fmt.Sprintf("%s %s reachable", network, addr) }
+	return p
+}
+
+// PortListening returns a [Predicate] that is ok when a TCP connection to port on localhost can be established within [PortTimeout], attempting as for [DialSucceeds].
+func PortListening(port int) Predicate {
+	return DialSucceeds("tcp", net.JoinHostPort("localhost", strconv.Itoa(port)), PortTimeout)
+}
+
+// dialOnce returns a [Predicate] that is ok when a single connection attempt succeeds.
+func dialOnce(network, addr string) Predicate {
+	var (
+		mu  sync.Mutex
+		err error
+	)
+
+	dial := func(ctx context.Context) bool {
+		var d net.Dialer
+		conn, e := d.DialContext(ctx, network, addr)
+		if e == nil {
+			conn.Close()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		err = e
+		return e == nil
+	}
+
+	return Predicate{
+		ok:    func() bool { return dial(context.Background()) },
+		okCtx: dial,
+		msg: func() string {
+			mu.Lock()
+			defer mu.Unlock()
+			return sprintf("expected to connect to %s %s: %v", network, addr, err)
+		},
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestDial(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().(*net.TCPAddr)
+
+	testspy.ExpectPass(t, observable.DialSucceeds("tcp", addr.String(), time.Second))
+	testspy.ExpectPass(t, observable.PortListening(addr.Port))
+	ln.Close()
+
+	p := observable.DialSucceeds("tcp", addr.String(), 120*time.Millisecond)
+	testspy.ExpectFail(t, p)
+	testspy.ExpectPass(t, observable.ContainsSubstring(p.Message(), "expected to connect to tcp "+addr.String()+": "))
+
+	// A server that comes up after the first attempt is still found.
+	late := make(chan net.Listener, 1)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		ln, _ := net.Listen("tcp", addr.String())
+		late <- ln
+	}()
+	testspy.ExpectPass(t, observable.DialSucceeds("tcp", addr.String(), 2*time.Second))
+	if ln := <-late; ln != nil {
+		ln.Close()
+	}
+}
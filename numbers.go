@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Signed is satisfied by the signed integer and floating-point types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+// Float is satisfied by the floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Ordered is satisfied by the types that support the < operator.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Positive returns a [Predicate] that is ok when x > 0.
+func Positive[T Signed](x T) Predicate {
+	return Predicate{
+		ok:   memo(func() bool { return x > 0 }),
+		msg:  func() string { return sprintf("expected positive value, got %v", x) },
+		neg:  func() string { return sprintf("expected non-positive value, got %v", x) },
+		desc: func() string { return "> 0" },
+	}
+}
+
+// Negative returns a [Predicate] that is ok when x < 0.
+func Negative[T Signed](x T) Predicate {
+	return Predicate{
+		ok:   memo(func() bool { return x < 0 }),
+		msg:  func() string { return sprintf("expected negative value, got %v", x) },
+		neg:  func() string { return sprintf("expected non-negative value, got %v", x) },
+		desc: func() string { return "< 0" },
+	}
+}
+
+// NonNegative returns a [Predicate] that is ok when x >= 0. NaN is not non-negative.
+func NonNegative[T Signed](x T) Predicate {
+	return Predicate{
+		ok:   memo(func() bool { return x >= 0 }),
+		msg:  func() string { return sprintf("expected non-negative value, got %v", x) },
+		neg:  func() string { return sprintf("expected negative value, got %v", x) },
+		desc: func() string { return ">= 0" },
+	}
+}
+
+// NonZero returns a [Predicate] that is ok when x != 0.
+func NonZero[T Signed](x T) Predicate {
+	return Predicate{
+		ok:   memo(func() bool { return x != 0 }),
+		msg:  func() string { return sprintf("expected non-zero value, got %v", x) },
+		neg:  func() string { return sprintf("expected zero, got %v", x) },
+		desc: func() string { return "!= 0" },
+	}
+}
+
+// IsNaN returns a [Predicate] that is ok when f is NaN. Use it rather than [Equal], which never holds for NaN.
+func IsNaN[T Float](f T) Predicate {
+	return Predicate{
+		ok:   memo(func() bool { return math.IsNaN(float64(f)) }),
+		msg:  func() string { return sprintf("expected NaN, got %v", f) },
+		neg:  func() string { return sprintf("expected a number, got NaN") },
+		desc: func() string { return "is NaN" },
+	}
+}
+
+// IsInf returns a [Predicate] that is ok when f is an infinity according to sign, as for [math.IsInf]: +Inf if sign > 0, -Inf if sign < 0, and either if sign == 0.
+func IsInf[T Float](f T, sign int) Predicate {
+	want := "±Inf"
+	switch {
+	case sign > 0:
+		want = "+Inf"
+	case sign < 0:
+		want = "-Inf"
+	}
+
+	return Predicate{
+		ok:   memo(func() bool { return math.IsInf(float64(f), sign) }),
+		msg:  func() string { return sprintf("expected %s, got %v", want, f) },
+		neg:  func() string { return sprintf("expected value other than %s, got %v", want, f) },
+		desc: func() string { return fmt.Sprintf("is %s", want) },
+	}
+}
+
+// Finite returns a [Predicate] that is ok when f is neither NaN nor an infinity.
+func Finite[T Float](f T) Predicate {
+	return Predicate{
+		ok: memo(func() bool {
+			x := float64(f)
+			return !math.IsNaN(x) && !math.IsInf(x, 0)
+		}),
+		msg:  func() string { return sprintf("expected finite value, got %v", f) },
+		neg:  func() string { return sprintf("expected NaN or infinity, got %v", f) },
+		desc: func() string { return "finite" },
+	}
+}
+
+// EqualNumeric returns a [Predicate] that is ok when got and want are numerically equal, whatever their integer or floating-point types, e.g. an int64 read from a database and an untyped int constant. Values are compared exactly: a negative number never equals an unsigned one, and a float only equals an integer when it is integral and within the integer type's range, so no conversion can overflow or round. EqualNumeric **panics** if either argument is not a number.
+func EqualNumeric(got, want any) Predicate {
+	g, w := numeric(got), numeric(want)
+
+	return Predicate{
+		ok:  memo(func() bool { return g.equal(w) }),
+		msg: func() string { return sprintf("expected %v (%T), got %v (%T)", want, want, got, got) },
+		neg: func() string {
+			return sprintf("expected numbers to differ, got %v (%T) and %v (%T)", got, got, want, want)
+		},
+		desc:   func() string { return fmt.Sprintf("%v == %v", got, want) },
+		values: func() (any, any) { return got, want },
+	}
+}
+
+// number holds a value of any numeric type in the representation that loses nothing: i for signed integers, u for unsigned ones and f for floats.
+type number struct {
+	kind byte // 'i', 'u' or 'f'
+	i    int64
+	u    uint64
+	f    float64
+}
+
+// numeric converts v to a [number], panicking if v is not of an integer or floating-point kind.
+func numeric(v any) number {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{kind: 'i', i: rv.Int()}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number{kind: 'u', u: rv.Uint()}
+	case reflect.Float32, reflect.Float64:
+		return number{kind: 'f', f: rv.Float()}
+	default:
+		panic(fmt.Sprintf("EqualNumeric: %v (%T) is not a number", v, v))
+	}
+}
+
+// equal reports whether n and m denote the same number.
+func (n number) equal(m number) bool {
+	if n.kind > m.kind {
+		n, m = m, n
+	}
+
+	// Kinds are ordered 'f' < 'i' < 'u'.
+	switch {
+	case n.kind == m.kind:
+		return n == m
+	case n.kind == 'i': // m is unsigned
+		return n.i >= 0 && uint64(n.i) == m.u
+	case m.kind == 'i': // n is a float
+		return n.f == math.Trunc(n.f) && n.f >= math.MinInt64 && n.f < -math.MinInt64 && int64(n.f) == m.i
+	default: // n is a float, m is unsigned
+		return n.f == math.Trunc(n.f) && n.f >= 0 && n.f < 2*-math.MinInt64 && uint64(n.f) == m.u
+	}
+}
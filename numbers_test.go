@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestSignChecks(t *testing.T) {
+	testspy.ExpectPass(t, observable.Positive(3))
+	testspy.ExpectFail(t, observable.Positive(0))
+	testspy.ExpectFail(t, observable.Positive(math.NaN()))
+	testspy.ExpectPass(t, observable.Positive(time.Second))
+
+	testspy.ExpectPass(t, observable.Negative(int8(-1)))
+	testspy.ExpectFail(t, observable.Negative(0.0))
+
+	testspy.ExpectPass(t, observable.NonNegative(0))
+	testspy.ExpectFail(t, observable.NonNegative(-0.5))
+
+	testspy.ExpectPass(t, observable.NonZero(int64(-7)))
+	testspy.ExpectFail(t, observable.NonZero(0))
+
+	if msg := observable.Positive(-2).Message(); msg != "expected positive value, got -2" {
+		t.Errorf("unexpected message: %q", msg)
+	}
+	if msg := observable.Not(observable.NonZero(5)).Message(); msg != "expected zero, got 5" {
+		t.Errorf("unexpected message: %q", msg)
+	}
+}
+
+func TestFloatChecks(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+
+	testspy.ExpectPass(t, observable.IsNaN(nan))
+	testspy.ExpectPass(t, observable.IsNaN(float32(nan)))
+	testspy.ExpectFail(t, observable.IsNaN(1.5))
+
+	testspy.ExpectPass(t, observable.IsInf(inf, 1))
+	testspy.ExpectPass(t, observable.IsInf(-inf, -1))
+	testspy.ExpectPass(t, observable.IsInf(-inf, 0))
+	testspy.ExpectFail(t, observable.IsInf(-inf, 1))
+	testspy.ExpectFail(t, observable.IsInf(nan, 0))
+
+	testspy.ExpectPass(t, observable.Finite(0.0))
+	testspy.ExpectFail(t, observable.Finite(nan))
+	testspy.ExpectFail(t, observable.Finite(float32(inf)))
+
+	if msg := observable.IsInf(2.5, -1).Message(); msg != "expected -Inf, got 2.5" {
+		t.Errorf("unexpected message: %q", msg)
+	}
+}
+
+func TestEqualNumeric(t *testing.T) {
+	type ID int64
+
+	testspy.ExpectPass(t, observable.EqualNumeric(int64(42), 42))
+	testspy.ExpectPass(t, observable.EqualNumeric(ID(7), uint8(7)))
+	testspy.ExpectPass(t, observable.EqualNumeric(float32(2.5), 2.5))
+	testspy.ExpectPass(t, observable.EqualNumeric(3.0, uint(3)))
+	testspy.ExpectPass(t, observable.EqualNumeric(-3.0, int8(-3)))
+	testspy.ExpectFail(t, observable.EqualNumeric(int64(42), 43))
+	testspy.ExpectFail(t, observable.EqualNumeric(-1, uint64(math.MaxUint64)))
+	testspy.ExpectFail(t, observable.EqualNumeric(2.5, 2))
+	testspy.ExpectFail(t, observable.EqualNumeric(math.NaN(), math.NaN()))
+	testspy.ExpectFail(t, observable.EqualNumeric(float64(1<<53), int64(1<<53+1)))
+	testspy.ExpectFail(t, observable.EqualNumeric(1e19, int64(math.MinInt64)))
+	testspy.ExpectFail(t, observable.EqualNumeric(-1.0, uint(0)))
+
+	want := "expected 43 (int), got 42 (int64)"
+	if msg := observable.EqualNumeric(int64(42), 43).Message(); msg != want {
+		t.Errorf("got message %q, want %q", msg, want)
+	}
+
+	testspy.ExpectPass(t, observable.Panics(func() { observable.EqualNumeric("1", 1) }))
+}
@@ -7,39 +7,249 @@
 package observable
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
 // Predicate encapsulates a lazily‑evaluated boolean condition together with a descriptive failure message.
 //
 // Generally, users will construct predicates with the helper functions in this package (e.g. [Nil], [Equal], [Panics]).The zero value is NOT valid.
 type Predicate struct {
-	ok  func() bool
-	msg func() string
+	ok   func() bool
+	msg  func() string
+	id   string
+	name string
+
+	// okCtx, if set, evaluates the condition while observing ctx, returning early once it is done.
+	okCtx func(ctx context.Context) bool
+	// asserted, if set, is called with the TB after the predicate has been evaluated by an assertion.
+	asserted func(tb testing.TB)
+
+	// desc, if set, returns a short description of the condition, e.g. "len == 3".
+	desc func() string
+	// neg, if set, is the message to report when the negation of this predicate fails, e.g. "expected values to differ".
+	neg func() string
+	// negated, if set, is the predicate this one negates, so that double negation can be undone.
+	negated *Predicate
+	// err, if set, returns an error that kept the condition from being evaluated, e.g. a malformed pattern. A predicate with an error is not ok, and neither is its negation.
+	err func() error
+	// values, if set, returns the values an equality predicate compares, which are written to separate artifacts when the failure message is too long to report inline.
+	values func() (got, want any)
+}
+
+// NewPredicate returns a [Predicate] built from an evaluation function and a message function. It is the building block for predicates defined outside this package. ok is called at most once; msg may be called more than once and only after ok.
+func NewPredicate(ok func() bool, msg func() string) Predicate {
+	return Predicate{ok: memo(ok), msg: msg}
 }
 
 // Ok evaluates and returns the underlying boolean condition.
 func (p Predicate) Ok() bool { return p.ok() }
 
-// Message returns the descriptive text explaining why the predicate failed.
-func (p Predicate) Message() string { return p.msg() }
+// Message returns the descriptive text explaining why the predicate failed. When the predicate carries an ID (see [Predicate.WithID]) or a name (see [Named]), the message is prefixed with them.
+func (p Predicate) Message() string { return p.label("") + p.msg() }
+
+// label renders the prefix identifying p in failure messages. loc, when non-empty, is the source location of the assertion and is shown after the name.
+func (p Predicate) label(loc string) string {
+	var prefix string
+	if p.id != "" {
+		prefix = fmt.Sprintf("[%s] ", p.id)
+	}
+
+	switch {
+	case p.name != "" && loc != "":
+		prefix += fmt.Sprintf("%s (%s): ", p.name, loc)
+	case p.name != "":
+		prefix += p.name + ": "
+	}
+
+	return prefix
+}
+
+// location returns the file:line of the assertion being evaluated, for named predicates only.
+func (p Predicate) location() string {
+	if p.name == "" {
+		return ""
+	}
+
+	file, line := callerLocation()
+
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
+
+// Named returns a copy of p with the given name. Failures of named predicates read "name (file.go:42): message", where the location is that of the assertion.
+func Named(name string, p Predicate) Predicate {
+	p.name = name
+	return p
+}
+
+// Name returns the name attached with [Named], or "" if there is none.
+func (p Predicate) Name() string { return p.name }
+
+// Describe returns a short human-readable description of the condition p checks, such as "err is not found" or "len == 3", suitable for naming subtests. It returns the name if p is [Named], and "predicate" if p has no description.
+func (p Predicate) Describe() string {
+	switch {
+	case p.name != "":
+		return p.name
+	case p.desc != nil:
+		return p.desc()
+	default:
+		return "predicate"
+	}
+}
+
+// WithID returns a copy of p that carries the stable identifier id, e.g. "ORD-042". The ID is included in failure messages so that tooling can route failures without matching on free-form message text.
+func (p Predicate) WithID(id string) Predicate {
+	p.id = id
+	return p
+}
+
+// WithNegatedMessage returns a copy of p that reports the message returned by neg when its negation (see [Not]) fails, e.g. "expected values to differ", instead of prefixing p's own message with "not: ". It is for predicates built with [NewPredicate] outside this package.
+func (p Predicate) WithNegatedMessage(neg func() string) Predicate {
+	p.neg = neg
+	return p
+}
+
+// WithError returns a copy of p that is not ok, and whose negation (see [Not]) is not ok either, when err returns a non-nil error, e.g. because the condition could not be evaluated. p's message should then report the error. It is for predicates built with [NewPredicate] outside this package.
+func (p Predicate) WithError(err func() error) Predicate {
+	ok := p.ok
+	p.ok = memo(func() bool { result := ok(); return err() == nil && result })
+	p.err = err
+	return p
+}
+
+// ID returns the identifier attached with [Predicate.WithID], or "" if there is none.
+func (p Predicate) ID() string { return p.id }
 
 // Assert evaluates the predicate and records an error on the [testing.TB] when the predicate is false.
 //
 // The returned bool is the evaluation result, which allows further composition or chaining inside a test when desired.
+//
+// tb may be nil, or a typed nil such as a nil *testing.T, where no test is running, e.g. in TestMain or when validating fixtures at init time. Assert then **panics** with the failure message instead of recording an error; use [Check] to handle the failure without panicking. The other asserting entry points ([Assertf], [Assertb], [OK], [MustOK], [Got], [AssertWithin], [AssertNoPanic] and the methods of an [Asserter]) do the same.
 func Assert(tb testing.TB, p Predicate) bool {
+	if nilTB(tb) {
+		return assertNil(p, "", func(p Predicate) string { return p.msg() })
+	}
+
 	tb.Helper()
+	return assert(tb, p, "", func(p Predicate) string { return p.msg() })
+}
+
+// Check evaluates p without a [testing.TB] and returns nil if it is ok, or an error whose text is the failure message otherwise, for use outside tests such as in TestMain. Observers registered with [Observe] are not notified.
+func Check(p Predicate) error {
+	p = recoverIfEnabled(p)
+	if p.Ok() {
+		return nil
+	}
+
+	return errors.New(p.Message())
+}
+
+// nilTB reports whether tb is nil, including a typed nil such as a nil *testing.T, in which case assertions panic on failure instead of recording an error.
+func nilTB(tb testing.TB) bool {
+	if tb == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(tb)
 
-	return observe(tb, p.Ok(), p.Message())
+	return v.Kind() == reflect.Ptr && v.IsNil()
 }
 
-// Assertf behaves like [Assert] but lets the caller supply an explicit failure message via format and args, similar to [fmt.Sprintf].
+// assertNil implements the assertions for a nil TB (see [nilTB]), panicking with the failure message, built as for assert, when p is not ok.
+func assertNil(p Predicate, prefix string, msg func(p Predicate) string) bool {
+	p = recoverIfEnabled(p)
+	if !p.Ok() {
+		panic(prefix + p.label("") + msg(p))
+	}
+
+	return true
+}
+
+// Assertf behaves like [Assert] but lets the caller supply an explicit failure message via format and args, similar to [fmt.Sprintf]. The predicate's ID and name, if any, are still included.
+//
+// The verb %P in format expands to the predicate's own failure message, so that custom context can be combined with the predicate's details, e.g. Assertf(t, Equal(got, want), "order %d: %P", id). It consumes no argument.
+//
+// As with [Assert], tb may be nil, in which case Assertf **panics** with the formatted message on failure.
 func Assertf(tb testing.TB, p Predicate, format string, args ...any) bool {
+	if nilTB(tb) {
+		return assertNil(p, "", func(p Predicate) string { return formatf(p, format, args) })
+	}
+
 	tb.Helper()
-	return observe(tb, p.Ok(), fmt.Sprintf(format, args...))
+	return assert(tb, p, "", func(p Predicate) string { return formatf(p, format, args) })
+}
+
+// formatf formats a custom failure message for p, expanding %P to p's message.
+func formatf(p Predicate, format string, args []any) string {
+	var sb strings.Builder
+	for i := 0; i < len(format); i++ {
+		switch {
+		case format[i] != '%' || i+1 == len(format):
+			sb.WriteByte(format[i])
+		case format[i+1] == 'P':
+			sb.WriteString(strings.ReplaceAll(p.msg(), "%", "%%"))
+			i++
+		default:
+			// Copy the verb's first character too, so that %% is never mistaken for the start of %P.
+			sb.WriteString(format[i : i+2])
+			i++
+		}
+	}
+
+	return fmt.Sprintf(sb.String(), args...)
+}
+
+// assert implements [Assert] and [Assertf]. On failure the message is prefix, p's label and then the result of msg; msg is only called when p is not ok.
+func assert(tb testing.TB, p Predicate, prefix string, msg func(p Predicate) string) bool {
+	tb.Helper()
+
+	ok, message := verdict(tb, p, prefix, msg)
+
+	return observe(tb, p.id, ok, message, p.values)
+}
+
+// verdict evaluates p for [assert] and returns whether it is ok and, if not, its failure message. It runs p's asserted hook and records test attributes for a failure, but neither fails tb nor notifies observers.
+func verdict(tb testing.TB, p Predicate, prefix string, msg func(p Predicate) string) (bool, string) {
+	tb.Helper()
+
+	p = recoverIfEnabled(p)
+
+	start := time.Now()
+	ok := p.Ok()
+	elapsed := time.Since(start)
+
+	if p.asserted != nil {
+		p.asserted(tb)
+	}
+	if ok {
+		return true, ""
+	}
+
+	detail := msg(p)
+	emitAttrs(tb, p, elapsed, detail)
+
+	return false, prefix + p.label(p.location()) + detail
+}
+
+// Assertb behaves like [Assert] but is sized for use inside b.N loops: when p is ok it returns immediately, without Helper bookkeeping, message construction or notifying observers registered with [Observe], so that validating results does not perturb measurements.
+func Assertb(b *testing.B, p Predicate) bool {
+	if p.Ok() {
+		return true
+	}
+	if b == nil {
+		return assertNil(p, "", func(p Predicate) string { return p.msg() })
+	}
+
+	b.Helper()
+
+	return observe(b, p.id, false, p.label(p.location())+p.msg(), p.values)
 }
 
 // That promotes a bool or bool-thunk to a [Predicate].
@@ -60,8 +270,10 @@ func That[T ~bool | ~func() bool](x T) Predicate {
 	}
 
 	return Predicate{
-		ok:  func() bool { eval(); return got },
-		msg: func() string { eval(); return fmt.Sprintf("expected true, got %v", got) },
+		ok:   func() bool { eval(); return got },
+		msg:  func() string { eval(); return sprintf("expected true, got %v", got) },
+		neg:  func() string { eval(); return sprintf("expected false, got %v", got) },
+		desc: func() string { return "holds" },
 	}
 }
 
@@ -71,15 +283,12 @@ func That[T ~bool | ~func() bool](x T) Predicate {
 // - [Predicate], resulting in a [Predicate]
 // - A function of any arity that returns a Predicate
 //
-// Negating a function of positive arity will use runtime reflection.
+// Negating a function of positive arity will use runtime reflection. Use [NotFn1], [NotFn2] or [NotFn3] to avoid it.
 //
 // Calling Not with anything else will **panic**!
 func Not[T any](a T) T {
 	if p, ok := any(a).(Predicate); ok {
-		return any(Predicate{
-			ok:  func() bool { return !p.Ok() },
-			msg: func() string { return fmt.Sprintf("not: %s", p.Message()) },
-		}).(T)
+		return any(negate(p)).(T)
 	}
 
 	// Handle nullary functions without reflection.
@@ -125,27 +334,94 @@ func Not[T any](a T) T {
 		out := rv.Call(callArgs)
 		p := out[0].Interface().(Predicate) // Original function returned a Predicate
 
-		negatedP := Predicate{
-			ok:  func() bool { return !p.Ok() },
-			msg: func() string { return fmt.Sprintf("not: %s", p.Message()) },
-		}
-		return []reflect.Value{reflect.ValueOf(negatedP)}
+		return []reflect.Value{reflect.ValueOf(negate(p))}
 	})
 
 	return wrapper.Interface().(T)
 }
 
-// observe is the common implementation used by [Assert] and [Assertf]. It reports a test error on tb when ok is false and returns ok so the caller can use the result in further logic.
+// NotFn1 returns the negation of a unary predicate constructor without the runtime reflection used by [Not].
+func NotFn1[A any](f func(A) Predicate) func(A) Predicate {
+	return func(a A) Predicate { return negate(f(a)) }
+}
+
+// NotFn2 returns the negation of a binary predicate constructor without the runtime reflection used by [Not], e.g. NotFn2(Equal[string]).
+func NotFn2[A, B any](f func(A, B) Predicate) func(A, B) Predicate {
+	return func(a A, b B) Predicate { return negate(f(a, b)) }
+}
+
+// NotFn3 returns the negation of a ternary predicate constructor without the runtime reflection used by [Not].
+func NotFn3[A, B, C any](f func(A, B, C) Predicate) func(A, B, C) Predicate {
+	return func(a A, b B, c C) Predicate { return negate(f(a, b, c)) }
+}
+
+// broken returns the error that kept p's condition from being evaluated, if any.
+func (p Predicate) broken() error {
+	if p.err == nil {
+		return nil
+	}
+	return p.err()
+}
+
+// negate returns the logical negation of p.
+//
+// Negating a negation returns the original predicate, and predicates that describe their own negation (e.g. [Equal]) report that description instead of a "not: " prefixed message. The negation keeps p's ID and name.
+func negate(p Predicate) Predicate {
+	if p.negated != nil {
+		return *p.negated
+	}
+
+	var okCtx func(ctx context.Context) bool
+	if p.okCtx != nil {
+		okCtx = func(ctx context.Context) bool { ok := p.okCtx(ctx); return p.broken() == nil && !ok }
+	}
+
+	return Predicate{
+		ok:    memo(func() bool { ok := p.Ok(); return p.broken() == nil && !ok }),
+		okCtx: okCtx,
+		err:   p.err,
+		id:    p.id,
+		name:  p.name,
+		msg: func() string {
+			if p.broken() != nil {
+				return p.msg()
+			}
+			if p.neg != nil {
+				return p.neg()
+			}
+			return sprintf("not: %s", p.msg())
+		},
+		desc:    func() string { return "not " + p.Describe() },
+		negated: &p,
+	}
+}
+
+// memo returns a function that calls ok on first use and returns its result from then on, so that a predicate evaluates its subject exactly once however often it is checked.
+func memo(ok func() bool) func() bool {
+	var (
+		once   sync.Once
+		result bool
+	)
+
+	return func() bool {
+		once.Do(func() { result = ok() })
+		return result
+	}
+}
+
+// observe is the common implementation used by [Assert] and [Assertf]. It notifies observers registered with [Observe], reports a test error on tb when ok is false and returns ok so the caller can use the result in further logic. values, if not nil, returns the compared values to attach to a message that is too long (see [SetMaxInlineMessage]).
 //
 //go:inline
-func observe(tb testing.TB, ok bool, message string) bool {
+func observe(tb testing.TB, id string, ok bool, message string, values func() (got, want any)) bool {
 	tb.Helper()
 
+	emit(tb, id, ok, message)
+
 	if ok {
 		return true
 	}
 
-	tb.Error(message)
+	tb.Error(attachArtifact(tb, message, values) + annotationSuffix(tb))
 
 	return false
 }
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"path"
+	"runtime"
+	"strings"
+)
+
+// CaseInsensitivePaths reports whether [PathEquals] and [PathHasSuffix] ignore case. It defaults to true on Windows and macOS, whose default filesystems are case-insensitive.
+var CaseInsensitivePaths = runtime.GOOS == "windows" || runtime.GOOS == "darwin"
+
+// normalizePath converts p to a canonical form for comparison: backslashes become slashes, the result is cleaned with [path.Clean], and it is lower-cased if [CaseInsensitivePaths] is set.
+func normalizePath(p string) string {
+	p = path.Clean(strings.ReplaceAll(p, `\`, "/"))
+	if CaseInsensitivePaths {
+		p = strings.ToLower(p)
+	}
+	return p
+}
+
+// PathEquals returns a [Predicate] that is ok when got and want name the same path once both are normalized: separators are unified, redundant elements such as "." and ".." are cleaned away, and case is ignored if [CaseInsensitivePaths] is set. The filesystem is not consulted.
+func PathEquals(got, want string) Predicate {
+	return Predicate{
+		ok: memo(func() bool { return normalizePath(got) == normalizePath(want) }),
+		msg: func() string {
+			return sprintf("expected path %q, got %q (normalized %q != %q)", want, got, normalizePath(got), normalizePath(want))
+		},
+		neg:    func() string { return sprintf("expected path other than %q, got %q", want, got) },
+		desc:   func() string { return fmt.Sprintf("path == %q", want) },
+		values: func() (any, any) { return got, want },
+	}
+}
+
+// PathHasSuffix returns a [Predicate] that is ok when the trailing elements of path p are those of suffix, after both are normalized as by [PathEquals]. Only whole elements match: "a/bc" does not have suffix "c".
+func PathHasSuffix(p, suffix string) Predicate {
+	return Predicate{
+		ok: memo(func() bool {
+			np, ns := normalizePath(p), normalizePath(suffix)
+			return np == ns || strings.HasSuffix(np, "/"+strings.TrimPrefix(ns, "/"))
+		}),
+		msg: func() string {
+			return sprintf("expected path %q to end with %q (normalized %q, %q)", p, suffix, normalizePath(p), normalizePath(suffix))
+		},
+		neg:  func() string { return sprintf("expected path %q not to end with %q", p, suffix) },
+		desc: func() string { return fmt.Sprintf("path has suffix %q", suffix) },
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestPathEquals(t *testing.T) {
+	testspy.ExpectPass(t, observable.PathEquals(`testdata\golden\out.txt`, "testdata/golden/out.txt"))
+	testspy.ExpectPass(t, observable.PathEquals("a/./b/../c/", "a/c"))
+	testspy.ExpectFail(t, observable.PathEquals("a/b", "a/c"))
+
+	defer func(v bool) { observable.CaseInsensitivePaths = v }(observable.CaseInsensitivePaths)
+
+	observable.CaseInsensitivePaths = true
+	testspy.ExpectPass(t, observable.PathEquals(`C:\Users\Go`, "c:/users/go"))
+
+	observable.CaseInsensitivePaths = false
+	testspy.ExpectFail(t, observable.PathEquals(`C:\Users\Go`, "c:/users/go"))
+}
+
+func TestPathHasSuffix(t *testing.T) {
+	testspy.ExpectPass(t, observable.PathHasSuffix(`C:\src\pkg\main.go`, "pkg/main.go"))
+	testspy.ExpectPass(t, observable.PathHasSuffix("/src/pkg/main.go", "/src/pkg/main.go"))
+	testspy.ExpectPass(t, observable.PathHasSuffix("/src/pkg/main.go", "./main.go"))
+	testspy.ExpectFail(t, observable.PathHasSuffix("/src/pkg/main.go", "ain.go"))
+	testspy.ExpectFail(t, observable.PathHasSuffix("main.go", "pkg/main.go"))
+}
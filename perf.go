@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// allocRuns is the number of times f is run when measuring allocations.
+const allocRuns = 100
+
+// AllocsPerRun returns a [Predicate] that is ok when f performs at most maxAllocs heap allocations per run on average, as measured by [testing.AllocsPerRun].
+func AllocsPerRun(f func(), maxAllocs int) Predicate {
+	var (
+		once   sync.Once
+		allocs float64
+	)
+
+	eval := func() { once.Do(func() { allocs = testing.AllocsPerRun(allocRuns, f) }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return allocs <= float64(maxAllocs) },
+		msg: func() string {
+			eval()
+			return sprintf("expected at most %d allocations per run, got %v", maxAllocs, allocs)
+		},
+	}
+}
+
+// MaxBytesAllocated returns a [Predicate] that is ok when f allocates at most maxBytes bytes of heap memory per run on average.
+func MaxBytesAllocated(f func(), maxBytes uint64) Predicate {
+	var (
+		once  sync.Once
+		bytes uint64
+	)
+
+	eval := func() { once.Do(func() { bytes = bytesPerRun(allocRuns, f) }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return bytes <= maxBytes },
+		msg: func() string {
+			eval()
+			return sprintf("expected at most %d bytes allocated per run, got %d", maxBytes, bytes)
+		},
+	}
+}
+
+// bytesPerRun returns the average number of heap bytes allocated by f, measured the same way [testing.AllocsPerRun] measures allocation counts.
+func bytesPerRun(runs int, f func()) uint64 {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	// Warm up the function.
+	f()
+
+	var memstats runtime.MemStats
+	runtime.ReadMemStats(&memstats)
+	before := memstats.TotalAlloc
+
+	for i := 0; i < runs; i++ {
+		f()
+	}
+
+	runtime.ReadMemStats(&memstats)
+
+	return (memstats.TotalAlloc - before) / uint64(runs)
+}
+
+// ThroughputOption configures [Throughput].
+type ThroughputOption func(*throughputConfig)
+
+type throughputConfig struct {
+	now func() time.Time
+}
+
+// ThroughputClock makes [Throughput] read the time from now instead of the wall clock, so that tests can drive the measurement window with a fake clock. With a custom clock the window ends when an item is emitted at or after its end, or when run returns; no wall-clock timer is used.
+func ThroughputClock(now func() time.Time) ThroughputOption {
+	return func(c *throughputConfig) { c.now = now }
+}
+
+// Throughput returns a [Predicate] that is ok when run emits at least minPerSecond items per second over a window of length window. run is started on first evaluation and passed an emit function to call for each item produced; items emitted after the window has ended are ignored. The measurement ends when the window does or when run returns, whichever is first, and the rate is always computed over the full window, so a stream that stops early counts against the rate.
+//
+// run keeps running in the background if it outlives the window; it should stop on its own, for example when its input is exhausted.
+func Throughput[T any](run func(emit func(T)), minPerSecond float64, window time.Duration, opts ...ThroughputOption) Predicate {
+	var cfg throughputConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	fake := cfg.now != nil
+	if !fake {
+		cfg.now = time.Now
+	}
+
+	var (
+		once  sync.Once
+		items int
+	)
+
+	eval := func() {
+		once.Do(func() {
+			var (
+				mu    sync.Mutex
+				count int
+				ended = make(chan struct{})
+				end   sync.Once
+			)
+			stop := func() { end.Do(func() { close(ended) }) }
+
+			deadline := cfg.now().Add(window)
+			emit := func(T) {
+				mu.Lock()
+				defer mu.Unlock()
+				select {
+				case <-ended:
+					return
+				default:
+				}
+				if !cfg.now().Before(deadline) {
+					stop()
+					return
+				}
+				count++
+			}
+
+			go func() {
+				defer stop()
+				run(emit)
+			}()
+
+			if fake {
+				<-ended
+			} else {
+				timer := time.NewTimer(window)
+				select {
+				case <-ended:
+				case <-timer.C:
+					stop()
+				}
+				timer.Stop()
+			}
+
+			mu.Lock()
+			items = count
+			mu.Unlock()
+		})
+	}
+
+	rate := func() float64 { return float64(items) / window.Seconds() }
+
+	return Predicate{
+		ok: func() bool { eval(); return rate() >= minPerSecond },
+		msg: func() string {
+			eval()
+			return sprintf("expected at least %.2f items/s over %v, got %.2f items/s (%d items)", minPerSecond, window, rate(), items)
+		},
+		desc: func() string { return fmt.Sprintf(">= %v items/s", minPerSecond) },
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+var sink []byte
+
+func TestAllocations(t *testing.T) {
+	none := func() {}
+	one := func() { sink = make([]byte, 1024) }
+
+	testspy.ExpectPass(t, observable.AllocsPerRun(none, 0))
+	testspy.ExpectPass(t, observable.AllocsPerRun(one, 1))
+	testspy.ExpectFail(t, observable.AllocsPerRun(one, 0))
+
+	testspy.ExpectPass(t, observable.MaxBytesAllocated(none, 0))
+	testspy.ExpectPass(t, observable.MaxBytesAllocated(one, 2048))
+	testspy.ExpectFail(t, observable.MaxBytesAllocated(one, 512))
+}
+
+// fakeClock is a manually advanced clock safe for concurrent use.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestThroughput(t *testing.T) {
+	// every emits an item each interval of fake time, forever.
+	every := func(clock *fakeClock, interval time.Duration) func(emit func(int)) {
+		return func(emit func(int)) {
+			for i := 0; i < 10000; i++ {
+				emit(i)
+				clock.Advance(interval)
+			}
+		}
+	}
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	testspy.ExpectPass(t, observable.Throughput(every(clock, 10*time.Millisecond), 100, time.Second, observable.ThroughputClock(clock.Now)))
+
+	clock = &fakeClock{now: time.Unix(0, 0)}
+	slow := observable.Throughput(every(clock, 20*time.Millisecond), 100, time.Second, observable.ThroughputClock(clock.Now))
+	testspy.ExpectFail(t, slow)
+	if msg, want := slow.Message(), "expected at least 100.00 items/s over 1s, got 50.00 items/s (50 items)"; msg != want {
+		t.Errorf("got message %q, want %q", msg, want)
+	}
+
+	short := func(emit func(int)) {
+		for i := 0; i < 3; i++ {
+			emit(i)
+		}
+	}
+	testspy.ExpectFail(t, observable.Throughput(short, 10, time.Second, observable.ThroughputClock(clock.Now)))
+	testspy.ExpectPass(t, observable.Throughput(short, 10, 100*time.Millisecond))
+
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+	blocked := func(emit func(int)) {
+		emit(0)
+		<-done
+	}
+	testspy.ExpectFail(t, observable.Throughput(blocked, 100, 50*time.Millisecond))
+}
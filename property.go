@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Generator produces pseudo-random values of type T from r.
+type Generator[T any] func(r *rand.Rand) T
+
+// PropertyOption configures [AgreeGen] and [Metamorphic].
+type PropertyOption func(*propertyConfig)
+
+type propertyConfig struct {
+	runs int
+	seed int64
+}
+
+// PropertyRuns sets the number of generated inputs [AgreeGen] and [Metamorphic] check. The default is 100.
+func PropertyRuns(n int) PropertyOption {
+	return func(c *propertyConfig) { c.runs = n }
+}
+
+// PropertySeed seeds the generator [AgreeGen] and [Metamorphic] draw inputs from, to reproduce a failure with the seed it reported. By default the seed is derived from the current time.
+func PropertySeed(seed int64) PropertyOption {
+	return func(c *propertyConfig) { c.seed = seed }
+}
+
+// Agree returns a [Predicate] that is ok when f1 and f2 produce [reflect.DeepEqual] outputs for every input. On failure the first diverging input is reported together with both outputs.
+func Agree[I, O any](inputs []I, f1, f2 func(I) O) Predicate {
+	var (
+		once     sync.Once
+		diverged bool
+		in       I
+		out1     O
+		out2     O
+	)
+
+	eval := func() {
+		once.Do(func() {
+			for _, x := range inputs {
+				if o1, o2 := f1(x), f2(x); !reflect.DeepEqual(o1, o2) {
+					diverged, in, out1, out2 = true, x, o1, o2
+					return
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return !diverged },
+		msg: func() string {
+			eval()
+			return sprintf("implementations disagree on input %#v\nfirst:  %#v\nsecond: %#v", in, out1, out2)
+		},
+	}
+}
+
+// AgreeGen is like [Agree] but draws its inputs from gen. The number of inputs and the seed can be set with [PropertyRuns] and [PropertySeed]; the seed used is reported on failure so that a run can be reproduced.
+func AgreeGen[I, O any](gen Generator[I], f1, f2 func(I) O, opts ...PropertyOption) Predicate {
+	cfg := propertyConfig{runs: 100, seed: time.Now().UnixNano()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		once sync.Once
+		p    Predicate
+	)
+
+	eval := func() {
+		once.Do(func() {
+			r := rand.New(rand.NewSource(cfg.seed))
+			inputs := make([]I, cfg.runs)
+			for i := range inputs {
+				inputs[i] = gen(r)
+			}
+			p = Agree(inputs, f1, f2)
+		})
+	}
+
+	return Predicate{
+		ok:  func() bool { eval(); return p.Ok() },
+		msg: func() string { eval(); return sprintf("%s\n(seed %d)", p.Message(), cfg.seed) },
+	}
+}
+
+// Metamorphic returns a [Predicate] that checks a metamorphic relation of f: for inputs drawn from gen, relate(f(in), f(transform(in))) must be ok. This allows testing functions that have no easy oracle, e.g. that sorting a sorted slice leaves it unchanged. The number of inputs and the seed can be set with [PropertyRuns] and [PropertySeed].
+//
+// On failure the first violating input, its transformation and the relation's message are reported along with the seed.
+func Metamorphic[I, O any](gen Generator[I], transform func(I) I, relate func(outOrig, outTransformed O) Predicate, f func(I) O, opts ...PropertyOption) Predicate {
+	cfg := propertyConfig{runs: 100, seed: time.Now().UnixNano()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		once     sync.Once
+		violated bool
+		in, tin  I
+		rel      Predicate
+	)
+
+	eval := func() {
+		once.Do(func() {
+			r := rand.New(rand.NewSource(cfg.seed))
+			for i := 0; i < cfg.runs; i++ {
+				x := gen(r)
+				tx := transform(x)
+				if p := relate(f(x), f(tx)); !p.Ok() {
+					violated, in, tin, rel = true, x, tx, p
+					return
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return !violated },
+		msg: func() string {
+			eval()
+			if !violated {
+				return sprintf("metamorphic relation held for %d inputs (seed %d)", cfg.runs, cfg.seed)
+			}
+			return sprintf("metamorphic relation violated for input %#v (transformed %#v): %s\n(seed %d)", in, tin, rel.Message(), cfg.seed)
+		},
+	}
+}
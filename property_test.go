@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func TestAgree(t *testing.T) {
+	square := func(x int) int { return x * x }
+	squareLoop := func(x int) int {
+		n := 0
+		for i := 0; i < abs(x); i++ {
+			n += abs(x)
+		}
+		return n
+	}
+	broken := func(x int) int { return x * abs(x) }
+
+	testspy.ExpectPass(t, observable.Agree([]int{-3, 0, 1, 7}, square, squareLoop))
+	testspy.ExpectFail(t, observable.Agree([]int{0, 1, -3, -4}, square, broken))
+
+	msg := observable.Agree([]int{0, 1, -3, -4}, square, broken).Message()
+	if !strings.Contains(msg, "input -3") {
+		t.Errorf("expected first diverging input in message, got %q", msg)
+	}
+
+	gen := observable.Generator[int](func(r *rand.Rand) int { return r.Intn(200) - 100 })
+	testspy.ExpectPass(t, observable.AgreeGen(gen, square, squareLoop))
+	testspy.ExpectFail(t, observable.AgreeGen(gen, square, broken, observable.PropertySeed(1)))
+
+	if msg := observable.AgreeGen(gen, square, broken, observable.PropertyRuns(100), observable.PropertySeed(42)).Message(); !strings.Contains(msg, "seed 42") {
+		t.Errorf("expected seed in message, got %q", msg)
+	}
+}
+
+func TestMetamorphic(t *testing.T) {
+	gen := observable.Generator[[]int](func(r *rand.Rand) []int { return r.Perm(r.Intn(20)) })
+	sorted := func(s []int) []int {
+		out := append([]int(nil), s...)
+		sort.Ints(out)
+		return out
+	}
+	reverse := func(s []int) []int {
+		out := make([]int, len(s))
+		for i, v := range s {
+			out[len(s)-1-i] = v
+		}
+		return out
+	}
+
+	testspy.ExpectPass(t, observable.Metamorphic(gen, sorted, observable.SequenceEqual[int], sorted))
+	testspy.ExpectPass(t, observable.Metamorphic(gen, reverse, observable.SequenceEqual[int], sorted))
+	testspy.ExpectFail(t, observable.Metamorphic(gen, reverse, observable.SequenceEqual[int], func(s []int) []int { return s }))
+
+	identity := func(s []int) []int { return s }
+	seeded := observable.Metamorphic(gen, reverse, observable.SequenceEqual[int], identity, observable.PropertySeed(42))
+	again := observable.Metamorphic(gen, reverse, observable.SequenceEqual[int], identity, observable.PropertySeed(42))
+	testspy.ExpectFail(t, seeded)
+	testspy.ExpectPass(t, observable.Equal(seeded.Message(), again.Message()))
+	testspy.ExpectPass(t, observable.HasSuffix(seeded.Message(), "\n(seed 42)"))
+
+	runs := 0
+	counted := observable.Generator[[]int](func(r *rand.Rand) []int { runs++; return gen(r) })
+	testspy.ExpectPass(t, observable.Metamorphic(counted, sorted, observable.SequenceEqual[int], sorted, observable.PropertyRuns(7)))
+	testspy.ExpectPass(t, observable.Equal(runs, 7))
+}
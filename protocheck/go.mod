@@ -0,0 +1,9 @@
+module renorm.dev/observable/protocheck
+
+go 1.22
+
+require renorm.dev/observable v0.0.0
+
+require google.golang.org/protobuf v1.36.6
+
+replace renorm.dev/observable => ../
@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+// Package protocheck provides predicates for protocol buffer messages.
+//
+// Messages must be compared with protobuf semantics rather than [observable.DeepEqual]: generated structs carry internal state, and equality is defined over populated fields, extensions and unknown fields. The package is a separate module so that observable itself stays dependency-free.
+package protocheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"renorm.dev/observable"
+)
+
+// Option configures [ProtoEqual].
+type Option func(*config)
+
+type config struct {
+	ignore []string
+}
+
+// Ignore makes [ProtoEqual] disregard the fields named by paths, given in field mask syntax, e.g. "metadata.create_time". Every component but the last must name a singular message field. ProtoEqual **panics** if a path does not name a field of the compared message type.
+func Ignore(paths ...string) Option {
+	return func(c *config) { c.ignore = append(c.ignore, paths...) }
+}
+
+// ProtoEqual returns a [observable.Predicate] that is ok when got and want are equal according to [proto.Equal]: both are of the same message type and have the same populated fields, extensions and unknown fields, with NaNs comparing equal. On failure the paths of the differing top-level and nested fields are listed.
+func ProtoEqual(got, want proto.Message, opts ...Option) observable.Predicate {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		once sync.Once
+		diff []string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			g, w := got, want
+			if len(cfg.ignore) > 0 && g != nil && w != nil {
+				g, w = proto.Clone(g), proto.Clone(w)
+				for _, path := range cfg.ignore {
+					clearPath(g.ProtoReflect(), path)
+					clearPath(w.ProtoReflect(), path)
+				}
+			}
+			if !proto.Equal(g, w) {
+				diff = diffMessages(g, w)
+			}
+		})
+	}
+
+	return observable.NewPredicate(
+		func() bool { eval(); return len(diff) == 0 },
+		func() string {
+			eval()
+			return "expected messages to be equal:\n  " + strings.Join(diff, "\n  ")
+		},
+	)
+}
+
+// clearPath clears the field at path in m, doing nothing if an enclosing message is unset.
+func clearPath(m protoreflect.Message, path string) {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			panic(fmt.Sprintf("protocheck: %s has no field %q in path %q", m.Descriptor().FullName(), name, path))
+		}
+		if i == len(names)-1 {
+			m.Clear(fd)
+			return
+		}
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			panic(fmt.Sprintf("protocheck: field %q in path %q is not a singular message", name, path))
+		}
+		if !m.Has(fd) {
+			return
+		}
+		m = m.Mutable(fd).Message()
+	}
+}
+
+// diffMessages describes how got and want differ, one entry per differing field.
+func diffMessages(got, want proto.Message) []string {
+	switch {
+	case got == nil || want == nil || !got.ProtoReflect().IsValid() || !want.ProtoReflect().IsValid():
+		return []string{fmt.Sprintf("(root): %v != %v", got, want)}
+	case got.ProtoReflect().Descriptor().FullName() != want.ProtoReflect().Descriptor().FullName():
+		return []string{fmt.Sprintf("(root): message type %s != %s", got.ProtoReflect().Descriptor().FullName(), want.ProtoReflect().Descriptor().FullName())}
+	}
+
+	return walk("", got.ProtoReflect(), want.ProtoReflect())
+}
+
+// walk compares the populated fields of got and want, recursing into singular message fields.
+func walk(path string, got, want protoreflect.Message) []string {
+	fields := map[protoreflect.FieldNumber]protoreflect.FieldDescriptor{}
+	collect := func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields[fd.Number()] = fd
+		return true
+	}
+	got.Range(collect)
+	want.Range(collect)
+
+	numbers := make([]protoreflect.FieldNumber, 0, len(fields))
+	for n := range fields {
+		numbers = append(numbers, n)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	var diff []string
+	for _, n := range numbers {
+		fd := fields[n]
+		p := path + "." + string(fd.Name())
+		if fd.IsExtension() {
+			p = path + ".[" + string(fd.FullName()) + "]"
+		}
+
+		switch {
+		case !got.Has(fd):
+			diff = append(diff, fmt.Sprintf("%s: <unset> != %s", p, format(fd, want.Get(fd))))
+		case !want.Has(fd):
+			diff = append(diff, fmt.Sprintf("%s: %s != <unset>", p, format(fd, got.Get(fd))))
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			diff = append(diff, walk(p, got.Get(fd).Message(), want.Get(fd).Message())...)
+		case !fieldEqual(got, want, fd):
+			diff = append(diff, fmt.Sprintf("%s: %s != %s", p, format(fd, got.Get(fd)), format(fd, want.Get(fd))))
+		}
+	}
+
+	if g, w := got.GetUnknown(), want.GetUnknown(); string(g) != string(w) {
+		p := path
+		if p == "" {
+			p = "(root)"
+		}
+		diff = append(diff, fmt.Sprintf("%s: unknown fields %x != %x", p, g, w))
+	}
+
+	return diff
+}
+
+// fieldEqual reports whether fd has equal values in got and want, by comparing messages holding only that field.
+func fieldEqual(got, want protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	g, w := got.New(), want.New()
+	g.Set(fd, got.Get(fd))
+	w.Set(fd, want.Get(fd))
+
+	return proto.Equal(g.Interface(), w.Interface())
+}
+
+// format renders the value v of field fd, spelling out the elements of lists and maps.
+func format(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch {
+	case fd.IsList():
+		elems := make([]string, v.List().Len())
+		for i := range elems {
+			elems[i] = fmt.Sprint(v.List().Get(i).Interface())
+		}
+		return "[" + strings.Join(elems, " ") + "]"
+	case fd.IsMap():
+		var entries []string
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			entries = append(entries, fmt.Sprintf("%v:%v", k.Interface(), v.Interface()))
+			return true
+		})
+		sort.Strings(entries)
+		return "map[" + strings.Join(entries, " ") + "]"
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package protocheck_test
+
+import (
+	"math"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+	"google.golang.org/protobuf/types/known/typepb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+	"renorm.dev/observable/protocheck"
+)
+
+func TestProtoEqual(t *testing.T) {
+	message := func(name, file string, opts ...string) *typepb.Type {
+		return &typepb.Type{Name: name, Oneofs: opts, SourceContext: &sourcecontextpb.SourceContext{FileName: file}}
+	}
+
+	testspy.ExpectPass(t, protocheck.ProtoEqual(message("a", "x.proto"), message("a", "x.proto")))
+	testspy.ExpectFail(t, protocheck.ProtoEqual(message("a", "x.proto"), message("b", "y.proto")))
+	testspy.ExpectPass(t, protocheck.ProtoEqual(message("a", "x.proto"), message("a", "y.proto"), protocheck.Ignore("source_context.file_name")))
+	testspy.ExpectPass(t, protocheck.ProtoEqual(message("a", "x.proto"), &typepb.Type{Name: "a"}, protocheck.Ignore("source_context")))
+	testspy.ExpectFail(t, protocheck.ProtoEqual(message("a", "x.proto"), wrapperspb.String("a")))
+	testspy.ExpectPass(t, protocheck.ProtoEqual(wrapperspb.Double(math.NaN()), wrapperspb.Double(math.NaN())))
+
+	unknown := wrapperspb.Int32(1)
+	unknown.ProtoReflect().SetUnknown(protowire.AppendTag(nil, 99, protowire.VarintType))
+	testspy.ExpectFail(t, protocheck.ProtoEqual(unknown, wrapperspb.Int32(1)))
+
+	msg := protocheck.ProtoEqual(message("a", "x.proto", "o"), message("b", "y.proto")).Message()
+	want := "expected messages to be equal:\n" +
+		"  .name: a != b\n" +
+		"  .oneofs: [o] != <unset>\n" +
+		"  .source_context.file_name: x.proto != y.proto"
+	if msg != want {
+		t.Errorf("got message\n%s\nwant\n%s", msg, want)
+	}
+
+	testspy.ExpectPass(t, observable.Panics(func() {
+		protocheck.ProtoEqual(message("a", "x.proto"), message("a", "x.proto"), protocheck.Ignore("source_context.nope")).Ok()
+	}))
+	testspy.ExpectPass(t, observable.Panics(func() {
+		protocheck.ProtoEqual(message("a", "x.proto"), message("a", "x.proto"), protocheck.Ignore("name.x")).Ok()
+	}))
+}
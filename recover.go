@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"sync"
+	"testing"
+)
+
+// RecoverPanics controls whether predicates are evaluated with panics recovered, reporting a panic as a failure with a stack trace instead of crashing the test binary. It applies to every assertion of this package, such as [Assert], [Assertf], [RetryAssert] and [Soak], to [Check], and to the operands of the combinators [All], [Any], [Predicate.And], [Predicate.Or], [Predicate.Xor] and [Predicate.Implies]. It is typically set once from TestMain.
+// RecoverPanics controls whether the assertions such as [Assert], [Assertf], [RetryAssert] and [Soak], and the combinators [All], [Any], [Predicate.And], [Predicate.Or], [Predicate.Xor] and [Predicate.Implies], recover panics raised while evaluating predicates, reporting them as failures with a stack trace instead of crashing the test binary. It is typically set once from TestMain.
+var RecoverPanics = false
+
+// AssertNoPanic runs f and records an error on the [testing.TB], including the panic value and stack trace, when f panics.
+func AssertNoPanic(tb testing.TB, f func()) bool {
+	if !nilTB(tb) {
+		tb.Helper()
+	}
+
+	var (
+		value    any
+		stack    string
+		panicked = true
+	)
+
+	func() {
+		defer func() {
+			if panicked {
+				value, stack = recover(), stackTrace(1)
+			}
+		}()
+		f()
+		panicked = false
+	}()
+
+	if nilTB(tb) {
+		return assertNil(That(!panicked), "", func(Predicate) string { return sprintf("unexpected panic: %v\n%s", value, stack) })
+	}
+
+	return observe(tb, "", !panicked, sprintf("unexpected panic: %v\n%s", value, stack), nil)
+}
+
+// Recover returns a copy of p whose evaluation recovers panics. A predicate that panics is not ok, and its message reports the panic value and stack trace.
+func Recover(p Predicate) Predicate {
+	var (
+		once     sync.Once
+		ok       bool
+		panicked bool
+		value    any
+		stack    string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			panicked = true
+			defer func() {
+				if panicked {
+					value, stack = recover(), stackTrace(1)
+				}
+			}()
+			ok = p.Ok()
+			panicked = false
+		})
+	}
+
+	r := p
+	r.negated = nil
+	r.ok = func() bool { eval(); return ok }
+	r.msg = func() (msg string) {
+		eval()
+		if panicked {
+			return sprintf("predicate panicked: %v\n%s", value, stack)
+		}
+
+		defer func() {
+			if v := recover(); v != nil {
+				msg = sprintf("predicate message panicked: %v\n%s", v, stackTrace(1))
+			}
+		}()
+
+		return p.msg()
+	}
+
+	return r
+}
+
+// recoverIfEnabled wraps p with [Recover] when [RecoverPanics] is set.
+func recoverIfEnabled(p Predicate) Predicate {
+	if RecoverPanics {
+		return Recover(p)
+	}
+	return p
+}
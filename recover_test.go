@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func panicking() observable.Predicate {
+	return observable.That(func() bool { panic("boom") })
+}
+
+func TestAssertNoPanic(t *testing.T) {
+	if !observable.AssertNoPanic(t, func() {}) {
+		t.Error("expected AssertNoPanic to pass for a function that returns")
+	}
+
+	spy := testspy.New(t)
+	if observable.AssertNoPanic(spy, func() { panic("boom") }) || !spy.SpiedOnFailure {
+		t.Fatal("expected AssertNoPanic to fail for a panicking function")
+	}
+	testspy.ExpectPass(t, observable.HasPrefix(spy.Messages[0], "unexpected panic: boom\nrenorm.dev/observable_test.TestAssertNoPanic"))
+}
+
+func TestRecover(t *testing.T) {
+	testspy.ExpectPass(t, observable.Recover(observable.True()))
+	testspy.ExpectFail(t, observable.Recover(panicking()))
+	testspy.ExpectPass(t, observable.HasPrefix(observable.Recover(panicking()).Message(), "predicate panicked: boom"))
+	testspy.ExpectPass(t, observable.Panics(func() { observable.All(observable.True(), panicking()).Ok() }))
+
+	defer func(v bool) { observable.RecoverPanics = v }(observable.RecoverPanics)
+	observable.RecoverPanics = true
+
+	testspy.ExpectFail(t, observable.All(observable.True(), panicking()))
+	testspy.ExpectPass(t, observable.Any(observable.True(), panicking()))
+
+	for _, p := range []observable.Predicate{
+		observable.True().And(panicking()),
+		panicking().Or(observable.False()),
+		observable.False().Xor(panicking()),
+		observable.True().Implies(panicking()),
+	} {
+		testspy.ExpectFail(t, p)
+		testspy.ExpectPass(t, observable.ContainsSubstring(p.Message(), "predicate panicked: boom"))
+	}
+	testspy.ExpectFail(t, panicking())
+}
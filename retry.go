@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"testing"
+	"time"
+)
+
+// RetryAssert evaluates a fresh predicate from f up to attempts times, sleeping delay between attempts, and records an error on the [testing.TB] only if none of them is ok. The failure message is that of the last attempt. When an assertion passes after retrying, the number of attempts is logged so that flaky checks remain visible. As with [Assert], tb may be nil, in which case RetryAssert **panics** with the failure message on failure.
+func RetryAssert(tb testing.TB, attempts int, delay time.Duration, f func() Predicate) bool {
+	if !nilTB(tb) {
+		tb.Helper()
+	}
+
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var p Predicate
+	for i := 1; i <= attempts; i++ {
+		p = recoverIfEnabled(f())
+		if p.Ok() {
+			if i > 1 && !nilTB(tb) {
+				tb.Logf("assertion passed after %d attempts", i)
+			}
+			break
+		}
+		if i < attempts {
+			time.Sleep(delay)
+		}
+	}
+
+	msg := func(p Predicate) string { return sprintf("failed after %d attempts: %s", attempts, p.msg()) }
+	if nilTB(tb) {
+		return assertNil(p, "", msg)
+	}
+
+	return assert(tb, p, "", msg)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+	"time"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestRetryAssert(t *testing.T) {
+	calls := 0
+	flaky := func() observable.Predicate {
+		calls++
+		return observable.That(calls >= 3)
+	}
+
+	if !observable.RetryAssert(t, 5, time.Millisecond, flaky) || calls != 3 {
+		t.Errorf("expected pass on third attempt, got %d calls", calls)
+	}
+
+	spy := testspy.New(t)
+	calls = 0
+	if observable.RetryAssert(spy, 2, time.Millisecond, flaky) || calls != 2 {
+		t.Fatalf("expected failure after 2 attempts, got %d calls", calls)
+	}
+	testspy.ExpectPass(t, observable.Equal(spy.Messages[0], "failed after 2 attempts: expected true, got false"))
+
+	spy = testspy.New(t)
+	observable.RetryAssert(spy, 1, 0, func() observable.Predicate { return observable.False().WithID("RETRY-1") })
+	testspy.ExpectPass(t, observable.Equal(spy.Messages[0], "[RETRY-1] failed after 1 attempts: false"))
+	testspy.ExpectPass(t, observable.Equal(spy.Attrs["observable.id"], "RETRY-1"))
+}
+
+func TestRetryAssertNilTB(t *testing.T) {
+	if !observable.RetryAssert(nil, 2, 0, observable.True) {
+		t.Error("expected nil-TB retry to pass")
+	}
+
+	defer func() {
+		if r := recover(); r != "failed after 2 attempts: expected true, got false" {
+			t.Errorf("got panic %v", r)
+		}
+	}()
+	observable.RetryAssert((*testing.T)(nil), 2, 0, func() observable.Predicate { return observable.That(false) })
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+//go:build go1.23
+
+package observable
+
+import (
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// SeqLimit caps the number of elements the sequence predicates ([SeqContains], [SeqLength], [SeqEqual] and [SeqAll], and their Seq2 counterparts) draw from a sequence, so that an accidentally infinite sequence fails the assertion instead of hanging the test.
+var SeqLimit = 1 << 20
+
+// collectSeq draws elements from seq until stop returns true for one of them, the sequence ends, or [SeqLimit] elements have been drawn, in which case capped is true.
+func collectSeq[T any](seq iter.Seq[T], stop func(i int, v T) bool) (n int, capped bool) {
+	limit := SeqLimit
+	for v := range seq {
+		if n == limit {
+			return n, true
+		}
+		n++
+		if stop(n-1, v) {
+			return n, false
+		}
+	}
+	return n, false
+}
+
+// SeqContains returns a [Predicate] that is ok when seq yields elem. Iteration stops at the first occurrence.
+func SeqContains[T comparable](seq iter.Seq[T], elem T) Predicate {
+	var (
+		once   sync.Once
+		found  bool
+		n      int
+		capped bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			n, capped = collectSeq(seq, func(_ int, v T) bool { found = v == elem; return found })
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return found },
+		msg: func() string {
+			eval()
+			if capped {
+				return sprintf("expected sequence to contain %v, not found in the first %d elements (SeqLimit)", elem, n)
+			}
+			return sprintf("expected sequence to contain %v, not found in %d elements", elem, n)
+		},
+		neg:  func() string { return sprintf("expected sequence not to contain %v", elem) },
+		desc: func() string { return fmt.Sprintf("contains %v", elem) },
+	}
+}
+
+// SeqLength returns a [Predicate] that is ok when seq yields exactly want elements. Iteration stops once more than want elements have been seen.
+func SeqLength[T any](seq iter.Seq[T], want int) Predicate {
+	var (
+		once   sync.Once
+		n      int
+		capped bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			n, capped = collectSeq(seq, func(i int, _ T) bool { return i >= want })
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return !capped && n == want },
+		msg: func() string {
+			eval()
+			switch {
+			case capped:
+				return sprintf("expected sequence length %d, got more than %d elements (SeqLimit)", want, n)
+			case n > want:
+				return sprintf("expected sequence length %d, got more", want)
+			default:
+				return sprintf("expected sequence length %d, got %d", want, n)
+			}
+		},
+		desc: func() string { return fmt.Sprintf("len == %d", want) },
+	}
+}
+
+// SeqEqual returns a [Predicate] that is ok when seq yields exactly the elements of want, in order. Iteration stops at the first difference.
+func SeqEqual[T comparable](seq iter.Seq[T], want []T) Predicate {
+	var (
+		once     sync.Once
+		n        int
+		capped   bool
+		diverged bool
+		got      T
+	)
+
+	eval := func() {
+		once.Do(func() {
+			n, capped = collectSeq(seq, func(i int, v T) bool {
+				if i >= len(want) || v != want[i] {
+					diverged, got = true, v
+				}
+				return diverged
+			})
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return !capped && !diverged && n == len(want) },
+		msg: func() string {
+			eval()
+			i := n - 1
+			switch {
+			case capped:
+				return sprintf("expected sequence %v, got more than %d elements (SeqLimit)", want, n)
+			case diverged && i >= len(want):
+				return sprintf("expected sequence %v, got extra element %v at index %d", want, got, i)
+			case diverged:
+				return sprintf("expected sequence %v, got %v at index %d, want %v", want, got, i, want[i])
+			default:
+				return sprintf("expected sequence %v, ended after %d elements", want, n)
+			}
+		},
+		desc: func() string { return fmt.Sprintf("sequence == %v", want) },
+	}
+}
+
+// SeqAll returns a [Predicate] that is ok when f returns an ok predicate for every element of seq. Iteration stops at the first failing element, which is reported with its index and message.
+func SeqAll[T any](seq iter.Seq[T], f func(T) Predicate) Predicate {
+	var (
+		once    sync.Once
+		n       int
+		capped  bool
+		failure Predicate
+		failed  bool
+	)
+
+	eval := func() {
+		once.Do(func() {
+			n, capped = collectSeq(seq, func(_ int, v T) bool {
+				if p := f(v); !p.Ok() {
+					failure, failed = p, true
+				}
+				return failed
+			})
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return !capped && !failed },
+		msg: func() string {
+			eval()
+			if capped {
+				return sprintf("expected every element to satisfy the predicate, sequence exceeded %d elements (SeqLimit)", n)
+			}
+			return sprintf("expected every element to satisfy the predicate, element %d failed: %s", n-1, failure.Message())
+		},
+		desc: func() string { return "all elements" },
+	}
+}
+
+// KV is a key/value pair yielded by an [iter.Seq2], as compared by [Seq2Equal].
+type KV[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// pairs adapts seq to an [iter.Seq] of its pairs.
+func pairs[K, V any](seq iter.Seq2[K, V]) iter.Seq[KV[K, V]] {
+	return func(yield func(KV[K, V]) bool) {
+		for k, v := range seq {
+			if !yield(KV[K, V]{k, v}) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2Contains returns a [Predicate] that is ok when seq yields the pair key, value. Iteration stops at the first occurrence.
+func Seq2Contains[K, V comparable](seq iter.Seq2[K, V], key K, value V) Predicate {
+	return SeqContains(pairs(seq), KV[K, V]{key, value})
+}
+
+// Seq2Length returns a [Predicate] that is ok when seq yields exactly want pairs. Iteration stops once more than want pairs have been seen.
+func Seq2Length[K, V any](seq iter.Seq2[K, V], want int) Predicate {
+	return SeqLength(pairs(seq), want)
+}
+
+// Seq2Equal returns a [Predicate] that is ok when seq yields exactly the pairs of want, in order. Iteration stops at the first difference.
+func Seq2Equal[K, V comparable](seq iter.Seq2[K, V], want []KV[K, V]) Predicate {
+	return SeqEqual(pairs(seq), want)
+}
+
+// Seq2All returns a [Predicate] that is ok when f returns an ok predicate for every pair of seq. Iteration stops at the first failing pair, which is reported with its index and message.
+func Seq2All[K, V any](seq iter.Seq2[K, V], f func(K, V) Predicate) Predicate {
+	return SeqAll(pairs(seq), func(kv KV[K, V]) Predicate { return f(kv.Key, kv.Value) })
+}
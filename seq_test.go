@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+//go:build go1.23
+
+package observable_test
+
+import (
+	"slices"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func naturals(yield func(int) bool) {
+	for i := 0; yield(i); i++ {
+	}
+}
+
+func TestSeqPredicates(t *testing.T) {
+	defer func(n int) { observable.SeqLimit = n }(observable.SeqLimit)
+	observable.SeqLimit = 1000
+
+	seq := slices.Values([]int{1, 2, 3})
+
+	testspy.ExpectPass(t, observable.SeqContains(seq, 2))
+	testspy.ExpectFail(t, observable.SeqContains(seq, 9))
+	testspy.ExpectPass(t, observable.SeqContains(naturals, 500))
+	testspy.ExpectFail(t, observable.SeqContains(naturals, -1))
+
+	testspy.ExpectPass(t, observable.SeqLength(seq, 3))
+	testspy.ExpectFail(t, observable.SeqLength(seq, 2))
+	testspy.ExpectFail(t, observable.SeqLength(seq, 4))
+	testspy.ExpectFail(t, observable.SeqLength(naturals, 3))
+
+	testspy.ExpectPass(t, observable.SeqEqual(seq, []int{1, 2, 3}))
+	testspy.ExpectFail(t, observable.SeqEqual(seq, []int{1, 2}))
+	testspy.ExpectFail(t, observable.SeqEqual(seq, []int{1, 2, 3, 4}))
+	testspy.ExpectFail(t, observable.SeqEqual(naturals, []int{0, 1}))
+
+	positive := func(n int) observable.Predicate { return observable.Positive(n) }
+	testspy.ExpectPass(t, observable.SeqAll(seq, positive))
+	testspy.ExpectFail(t, observable.SeqAll(naturals, positive))
+	testspy.ExpectFail(t, observable.SeqAll(naturals, func(int) observable.Predicate { return observable.True() }))
+
+	for _, tc := range []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.SeqContains(naturals, -1), "expected sequence to contain -1, not found in the first 1000 elements (SeqLimit)"},
+		{observable.SeqEqual(seq, []int{1, 5, 3}), "expected sequence [1 5 3], got 2 at index 1, want 5"},
+		{observable.SeqEqual(seq, []int{1, 2}), "expected sequence [1 2], got extra element 3 at index 2"},
+		{observable.SeqEqual(seq, []int{1, 2, 3, 4}), "expected sequence [1 2 3 4], ended after 3 elements"},
+		{observable.SeqAll(naturals, positive), "expected every element to satisfy the predicate, element 0 failed: expected positive value, got 0"},
+	} {
+		if msg := tc.p.Message(); msg != tc.want {
+			t.Errorf("got message %q, want %q", msg, tc.want)
+		}
+	}
+}
+
+func TestSeq2Predicates(t *testing.T) {
+	letters := []string{"a", "b", "c"}
+	squares := func(yield func(int, int) bool) {
+		for i := 0; yield(i, i*i); i++ {
+		}
+	}
+
+	testspy.ExpectPass(t, observable.Seq2Contains(slices.All(letters), 1, "b"))
+	testspy.ExpectFail(t, observable.Seq2Contains(slices.All(letters), 2, "b"))
+	testspy.ExpectPass(t, observable.Seq2Contains(squares, 12, 144))
+
+	testspy.ExpectPass(t, observable.Seq2Length(slices.All(letters), 3))
+	testspy.ExpectFail(t, observable.Seq2Length(squares, 3))
+
+	testspy.ExpectPass(t, observable.Seq2Equal(slices.All(letters), []observable.KV[int, string]{{0, "a"}, {1, "b"}, {2, "c"}}))
+	p := observable.Seq2Equal(slices.All(letters), []observable.KV[int, string]{{0, "a"}, {1, "x"}, {2, "c"}})
+	testspy.ExpectFail(t, p)
+	testspy.ExpectPass(t, observable.ContainsSubstring(p.Message(), "got {1 b} at index 1, want {1 x}"))
+
+	testspy.ExpectPass(t, observable.Seq2All(slices.All(letters), func(i int, s string) observable.Predicate { return observable.Equal(s, letters[i]) }))
+	testspy.ExpectFail(t, observable.Seq2All(squares, func(i, sq int) observable.Predicate { return observable.That(sq < 50) }))
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"hash/fnv"
+	"testing"
+)
+
+// InShard reports whether the test case called name belongs to shard, numbered from 0, of totalShards. Cases are assigned by a stable hash (FNV-1a) of name alone, so a case stays on the same shard across runs, machines and Go versions, and adding a case never moves the others. InShard **panics** if totalShards < 1 or shard is not in [0, totalShards).
+func InShard(t testing.TB, name string, shard, totalShards int) bool {
+	t.Helper()
+
+	if totalShards < 1 || shard < 0 || shard >= totalShards {
+		panic(fmt.Sprintf("observable: InShard: shard %d out of range for %d shards", shard, totalShards))
+	}
+
+	return shardOf(name, totalShards) == shard
+}
+
+// SkipUnlessShard skips t unless the test case called name belongs to shard of totalShards, as determined by [InShard].
+func SkipUnlessShard(t testing.TB, name string, shard, totalShards int) {
+	t.Helper()
+
+	if !InShard(t, name, shard, totalShards) {
+		t.Skipf("%s is in shard %d of %d, running shard %d", name, shardOf(name, totalShards), totalShards, shard)
+	}
+}
+
+// shardOf returns the shard of totalShards that name hashes to.
+func shardOf(name string, totalShards int) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+
+	return int(h.Sum32() % uint32(totalShards))
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestInShard(t *testing.T) {
+	counts := make([]int, 4)
+	for i := 0; i < 400; i++ {
+		name := fmt.Sprintf("case-%d", i)
+		in := 0
+		for shard := range counts {
+			if observable.InShard(t, name, shard, len(counts)) {
+				counts[shard]++
+				in++
+			}
+		}
+		if in != 1 {
+			t.Fatalf("expected %s to be in exactly one shard, got %d", name, in)
+		}
+	}
+	for shard, n := range counts {
+		if n < 50 {
+			t.Errorf("expected shards to be balanced, shard %d has %d of 400 cases", shard, n)
+		}
+	}
+
+	// FNV-1a("a") = 0xe40c292c, which is 0 mod 4 and 5 mod 7.
+	if !observable.InShard(t, "a", 0, 4) || !observable.InShard(t, "a", 5, 7) {
+		t.Error("expected shard assignment to be stable")
+	}
+	if !observable.InShard(t, "anything", 0, 1) {
+		t.Error("expected a single shard to hold every case")
+	}
+
+	testspy.ExpectPass(t, observable.Panics(func() { observable.InShard(t, "a", 0, 0) }))
+	testspy.ExpectPass(t, observable.Panics(func() { observable.InShard(t, "a", 4, 4) }))
+	testspy.ExpectPass(t, observable.Panics(func() { observable.InShard(t, "a", -1, 4) }))
+}
+
+func TestSkipUnlessShard(t *testing.T) {
+	var skipped, ran bool
+	t.Run("other", func(t *testing.T) {
+		defer func() { skipped = t.Skipped() }()
+		observable.SkipUnlessShard(t, "a", 1, 4)
+	})
+	t.Run("own", func(t *testing.T) {
+		observable.SkipUnlessShard(t, "a", 0, 4)
+		ran = true
+	})
+
+	if !skipped {
+		t.Error("expected case outside the shard to be skipped")
+	}
+	if !ran {
+		t.Error("expected case in the shard to run")
+	}
+}
@@ -5,14 +5,26 @@ package observable
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 )
 
 // Length returns a [Predicate] that is ok when len(s) == want.
 func Length[T any](s []T, want int) Predicate {
 	return Predicate{
-		ok:  func() bool { return len(s) == want },
-		msg: func() string { return fmt.Sprintf("expected length %d, got %d", want, len(s)) },
+		ok:   memo(func() bool { return len(s) == want }),
+		msg:  func() string { return sprintf("expected length %d, got %d", want, len(s)) },
+		desc: func() string { return fmt.Sprintf("len == %d", want) },
+	}
+}
+
+// Cap returns a [Predicate] that is ok when cap(s) == want, e.g. to check that a slice was pre-allocated.
+func Cap[T any](s []T, want int) Predicate {
+	return Predicate{
+		ok:   memo(func() bool { return cap(s) == want }),
+		msg:  func() string { return sprintf("expected capacity %d, got %d (length %d)", want, cap(s), len(s)) },
+		desc: func() string { return fmt.Sprintf("cap == %d", want) },
 	}
 }
 
@@ -22,22 +34,24 @@ func Empty[T any](s []T) Predicate { return Length(s, 0) }
 // Contains returns a [Predicate] that is ok when elem is present in slice.
 func Contains[T comparable](slice []T, elem T) Predicate {
 	return Predicate{
-		ok: func() bool {
+		ok: memo(func() bool {
 			for _, v := range slice {
 				if v == elem {
 					return true
 				}
 			}
 			return false
-		},
-		msg: func() string { return fmt.Sprintf("expected %v to contain %v", slice, elem) },
+		}),
+		msg:  func() string { return sprintf("expected %v to contain %v", slice, elem) },
+		neg:  func() string { return sprintf("expected %v not to contain %v", slice, elem) },
+		desc: func() string { return fmt.Sprintf("contains %v", elem) },
 	}
 }
 
 // SequenceEqual returns a [Predicate] that is ok when got and want have identical length and elements appear in the same order.
 func SequenceEqual[T comparable](got, want []T) Predicate {
 	return Predicate{
-		ok: func() bool {
+		ok: memo(func() bool {
 			if len(got) != len(want) {
 				return false
 			}
@@ -47,8 +61,47 @@ func SequenceEqual[T comparable](got, want []T) Predicate {
 				}
 			}
 			return true
+		}),
+		msg:    func() string { return sprintf("expected slice %v, got %v", want, got) },
+		desc:   func() string { return fmt.Sprintf("sequence == %v", want) },
+		values: func() (any, any) { return got, want },
+	}
+}
+
+// SequenceEqualFunc returns a [Predicate] that is ok when got and want have identical length and eq reports true for each pair of elements at the same index. On failure the first differing index is reported. SequenceEqualFunc **panics** if eq is nil.
+func SequenceEqualFunc[T any](got, want []T, eq func(got, want T) bool) Predicate {
+	if eq == nil {
+		panic("observable: SequenceEqualFunc: nil eq")
+	}
+
+	var (
+		once sync.Once
+		diff = -1
+	)
+
+	eval := func() {
+		once.Do(func() {
+			for i := 0; i < len(got) && i < len(want); i++ {
+				if !eq(got[i], want[i]) {
+					diff = i
+					return
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return diff < 0 && len(got) == len(want) },
+		msg: func() string {
+			eval()
+			if diff < 0 {
+				return sprintf("expected slice %+v, got %+v: length %d, want %d", want, got, len(got), len(want))
+			}
+			return sprintf("expected slice %+v, got %+v: first difference at index %d: got %+v, want %+v", want, got, diff, got[diff], want[diff])
 		},
-		msg: func() string { return fmt.Sprintf("expected slice %v, got %v", want, got) },
+		neg:    func() string { return sprintf("expected slices to differ, got %+v", got) },
+		desc:   func() string { return fmt.Sprintf("sequence equals %+v", want) },
+		values: func() (any, any) { return got, want },
 	}
 }
 
@@ -68,37 +121,369 @@ func SequenceDeepEqual[T any](got, want []T) Predicate {
 		},
 		msg: func() string {
 			check()
-			return fmt.Sprintf("expected slice %v, got %v", want, got)
+			return sprintf("expected slice %v, got %v", want, got)
 		},
+		desc:   func() string { return fmt.Sprintf("sequence == %v", want) },
+		values: func() (any, any) { return got, want },
 	}
 }
 
-// ElementsMatch returns a [Predicate] that is ok when the two slices contain the same multiset of elements, irrespective of order.
+// ElementsMatch returns a [Predicate] that is ok when the two slices contain the same multiset of elements, irrespective of order. On failure the elements missing from got, the extra elements in got and the elements present in both but a different number of times are listed, rather than both slices in full.
 func ElementsMatch[T comparable](got, want []T) Predicate {
-	count := func(s []T) map[T]int {
-		m := make(map[T]int, len(s))
-		for _, v := range s {
-			m[v]++
+	var (
+		once                     sync.Once
+		missing, extra, mismatch []T
+		gotCount, wantCount      map[T]int
+	)
+
+	check := func() {
+		once.Do(func() {
+			gotCount, wantCount = make(map[T]int, len(got)), make(map[T]int, len(want))
+			for _, v := range got {
+				gotCount[v]++
+			}
+			for _, v := range want {
+				wantCount[v]++
+			}
+
+			// Elements are listed once each, in order of first appearance.
+			seen := make(map[T]bool, len(wantCount))
+			for _, v := range want {
+				if seen[v] {
+					continue
+				}
+				seen[v] = true
+				switch g := gotCount[v]; {
+				case g == 0:
+					missing = append(missing, v)
+				case g != wantCount[v]:
+					mismatch = append(mismatch, v)
+				}
+			}
+			for _, v := range got {
+				if !seen[v] {
+					seen[v] = true
+					extra = append(extra, v)
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool {
+			check()
+			return len(missing) == 0 && len(extra) == 0 && len(mismatch) == 0
+		},
+		msg: func() string {
+			check()
+
+			var sb strings.Builder
+			sb.WriteString(sprintf("expected slices to contain the same elements (got %d, want %d)", len(got), len(want)))
+			if len(missing) > 0 {
+				sb.WriteString(sprintf("\nmissing from got: %v", missing))
+			}
+			if len(extra) > 0 {
+				sb.WriteString(sprintf("\nextra in got:     %v", extra))
+			}
+			if len(mismatch) > 0 {
+				counts := make([]string, len(mismatch))
+				for i, v := range mismatch {
+					counts[i] = sprintf("%v (got %d, want %d)", v, gotCount[v], wantCount[v])
+				}
+				sb.WriteString(sprintf("\ncount mismatch:   %s", strings.Join(counts, ", ")))
+			}
+			return sb.String()
+		},
+		desc:   func() string { return fmt.Sprintf("elements match %v", want) },
+		values: func() (any, any) { return got, want },
+	}
+}
+
+// SubsetOf returns a [Predicate] that is ok when every element of got is present in universe. On failure the elements of got missing from universe are listed.
+func SubsetOf[T comparable](got, universe []T) Predicate {
+	var (
+		once    sync.Once
+		outside []T
+	)
+
+	eval := func() { once.Do(func() { outside = difference(got, universe) }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return len(outside) == 0 },
+		msg: func() string {
+			eval()
+			return sprintf("expected %v to be a subset of %v, extra elements: %v", got, universe, outside)
+		},
+		desc: func() string { return fmt.Sprintf("subset of %v", universe) },
+	}
+}
+
+// SupersetOf returns a [Predicate] that is ok when every element of subset is present in got. On failure the elements of subset missing from got are listed.
+func SupersetOf[T comparable](got, subset []T) Predicate {
+	var (
+		once    sync.Once
+		missing []T
+	)
+
+	eval := func() { once.Do(func() { missing = difference(subset, got) }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return len(missing) == 0 },
+		msg: func() string {
+			eval()
+			return sprintf("expected %v to be a superset of %v, missing elements: %v", got, subset, missing)
+		},
+		desc: func() string { return fmt.Sprintf("superset of %v", subset) },
+	}
+}
+
+// Disjoint returns a [Predicate] that is ok when a and b have no elements in common. On failure the common elements are listed.
+func Disjoint[T comparable](a, b []T) Predicate {
+	var (
+		once   sync.Once
+		common []T
+	)
+
+	eval := func() {
+		once.Do(func() {
+			in := make(map[T]bool, len(b))
+			for _, v := range b {
+				in[v] = true
+			}
+			for _, v := range a {
+				if in[v] {
+					common = append(common, v)
+					delete(in, v)
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return len(common) == 0 },
+		msg: func() string {
+			eval()
+			return sprintf("expected %v and %v to be disjoint, common elements: %v", a, b, common)
+		},
+		desc: func() string { return fmt.Sprintf("disjoint from %v", b) },
+	}
+}
+
+// difference returns the distinct elements of a that are not present in b, in order of first appearance.
+func difference[T comparable](a, b []T) []T {
+	in := make(map[T]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+
+	var diff []T
+	for _, v := range a {
+		if !in[v] {
+			diff = append(diff, v)
+			in[v] = true
 		}
+	}
+
+	return diff
+}
+
+// Unique returns a [Predicate] that is ok when s contains no duplicate elements. On failure each duplicated element is reported with the indices at which it occurs.
+func Unique[T comparable](s []T) Predicate {
+	var (
+		once       sync.Once
+		duplicates []string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			indices := make(map[T][]int, len(s))
+			for i, v := range s {
+				indices[v] = append(indices[v], i)
+			}
+			for i, v := range s {
+				if idx := indices[v]; len(idx) > 1 && idx[0] == i {
+					duplicates = append(duplicates, sprintf("%v at indices %v", v, idx))
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return len(duplicates) == 0 },
+		msg: func() string {
+			eval()
+			return sprintf("expected unique elements, found duplicates: %s", strings.Join(duplicates, "; "))
+		},
+		desc: func() string { return "unique" },
+	}
+}
+
+// AllElements returns a [Predicate] that is ok when f returns an ok predicate for every element of s. On failure each failing index is reported with its message.
+func AllElements[T any](s []T, f func(T) Predicate) Predicate {
+	var (
+		once     sync.Once
+		failures []string
+	)
 
-		return m
+	eval := func() {
+		once.Do(func() {
+			for i, v := range s {
+				if p := f(v); !p.Ok() {
+					failures = append(failures, fmt.Sprintf("[%d]: %s", i, p.Message()))
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return len(failures) == 0 },
+		msg: func() string {
+			eval()
+			return sprintf("expected all %d elements to satisfy the predicate, %d failed:\n  %s", len(s), len(failures), strings.Join(failures, "\n  "))
+		},
+		desc: func() string { return "all elements" },
+	}
+}
+
+// AnyElement returns a [Predicate] that is ok when f returns an ok predicate for at least one element of s. On failure each index is reported with its message.
+func AnyElement[T any](s []T, f func(T) Predicate) Predicate {
+	var (
+		once     sync.Once
+		found    bool
+		failures []string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			for i, v := range s {
+				p := f(v)
+				if p.Ok() {
+					found = true
+					return
+				}
+				failures = append(failures, fmt.Sprintf("[%d]: %s", i, p.Message()))
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return found },
+		msg: func() string {
+			eval()
+			if len(s) == 0 {
+				return sprintf("expected an element to satisfy the predicate, slice is empty")
+			}
+			return sprintf("expected an element to satisfy the predicate, all %d failed:\n  %s", len(s), strings.Join(failures, "\n  "))
+		},
+		desc: func() string { return "any element" },
+	}
+}
+
+// closestCandidates is the number of elements listed by [ContainsWhere] on failure.
+const closestCandidates = 3
+
+// ContainsWhere returns a [Predicate] that is ok when match returns true for at least one element of s; describe says what is searched for, e.g. "order with ID 7 and status paid". On failure the closest candidates are listed: those with the highest score if a scoring function is given, and the first elements of s otherwise. At most one scoring function may be given; ContainsWhere **panics** otherwise.
+func ContainsWhere[T any](s []T, match func(T) bool, describe string, score ...func(T) float64) Predicate {
+	if len(score) > 1 {
+		panic("ContainsWhere: at most one scoring function may be given")
 	}
 
 	var (
 		once  sync.Once
-		match bool
+		found bool
 	)
 
-	check := func() { once.Do(func() { match = reflect.DeepEqual(count(got), count(want)) }) }
+	eval := func() {
+		once.Do(func() {
+			for _, v := range s {
+				if match(v) {
+					found = true
+					return
+				}
+			}
+		})
+	}
 
 	return Predicate{
-		ok: func() bool {
-			check()
-			return match
+		ok: func() bool { eval(); return found },
+		msg: func() string {
+			eval()
+			if len(s) == 0 {
+				return sprintf("expected slice to contain %s, slice is empty", describe)
+			}
+
+			idx := make([]int, len(s))
+			for i := range idx {
+				idx[i] = i
+			}
+			label := "first"
+			if len(score) == 1 {
+				scores := make([]float64, len(s))
+				for i, v := range s {
+					scores[i] = score[0](v)
+				}
+				sort.SliceStable(idx, func(a, b int) bool { return scores[idx[a]] > scores[idx[b]] })
+				label = "closest"
+			}
+			if len(idx) > closestCandidates {
+				idx = idx[:closestCandidates]
+			}
+
+			candidates := make([]string, len(idx))
+			for i, j := range idx {
+				candidates[i] = fmt.Sprintf("[%d]: %+v", j, s[j])
+			}
+			return sprintf("expected slice of %d elements to contain %s; %s candidates:\n  %s", len(s), describe, label, strings.Join(candidates, "\n  "))
 		},
+		neg:  func() string { return sprintf("expected slice not to contain %s", describe) },
+		desc: func() string { return "contains " + describe },
+	}
+}
+
+// Increasing returns a [Predicate] that is ok when every element of s is greater than or equal to the one before it. On failure the first violating pair is reported.
+func Increasing[T Ordered](s []T) Predicate {
+	return monotonic(s, "non-decreasing", func(a, b T) bool { return a <= b })
+}
+
+// StrictlyIncreasing returns a [Predicate] that is ok when every element of s is greater than the one before it. On failure the first violating pair is reported.
+func StrictlyIncreasing[T Ordered](s []T) Predicate {
+	return monotonic(s, "strictly increasing", func(a, b T) bool { return a < b })
+}
+
+// Decreasing returns a [Predicate] that is ok when every element of s is less than or equal to the one before it. On failure the first violating pair is reported.
+func Decreasing[T Ordered](s []T) Predicate {
+	return monotonic(s, "non-increasing", func(a, b T) bool { return a >= b })
+}
+
+// StrictlyDecreasing returns a [Predicate] that is ok when every element of s is less than the one before it. On failure the first violating pair is reported.
+func StrictlyDecreasing[T Ordered](s []T) Predicate {
+	return monotonic(s, "strictly decreasing", func(a, b T) bool { return a > b })
+}
+
+// monotonic implements the monotonicity predicates; inOrder reports whether b may follow a.
+func monotonic[T Ordered](s []T, order string, inOrder func(a, b T) bool) Predicate {
+	var (
+		once sync.Once
+		at   = -1
+	)
+
+	eval := func() {
+		once.Do(func() {
+			for i := 1; i < len(s); i++ {
+				if !inOrder(s[i-1], s[i]) {
+					at = i
+					return
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return at < 0 },
 		msg: func() string {
-			check()
-			return fmt.Sprintf("expected %v and %v to contain the same elements", got, want)
+			eval()
+			return sprintf("expected %s sequence, [%d] = %v is followed by [%d] = %v", order, at-1, s[at-1], at, s[at])
 		},
+		desc: func() string { return order },
 	}
 }
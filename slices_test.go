@@ -3,6 +3,7 @@
 package observable_test
 
 import (
+	"strings"
 	"testing"
 
 	"renorm.dev/observable"
@@ -27,3 +28,148 @@ func TestSliceAsserts(t *testing.T) {
 
 	testspy.ExpectFail(t, observable.Empty(foo))
 }
+
+func TestElementsMatchMessage(t *testing.T) {
+	msg := observable.ElementsMatch([]string{"a", "c", "c", "d"}, []string{"d", "a", "b", "c"}).Message()
+
+	testspy.ExpectPass(t, observable.ContainsSubstring(msg, "missing from got: [b]"))
+	testspy.ExpectPass(t, observable.ContainsSubstring(msg, "count mismatch:   c (got 2, want 1)"))
+	testspy.ExpectFail(t, observable.ElementsMatch([]int{1, 1, 2}, []int{1, 2, 2}))
+
+	msg = observable.ElementsMatch([]int{1, 1, 2, 7, 7}, []int{1, 2, 2, 3}).Message()
+	want := "expected slices to contain the same elements (got 5, want 4)\n" +
+		"missing from got: [3]\n" +
+		"extra in got:     [7]\n" +
+		"count mismatch:   1 (got 2, want 1), 2 (got 1, want 2)"
+	if msg != want {
+		t.Errorf("got message\n%s\nwant\n%s", msg, want)
+	}
+}
+
+func TestSetPredicates(t *testing.T) {
+	perms := []string{"read", "write"}
+	all := []string{"read", "write", "admin"}
+
+	testspy.ExpectPass(t, observable.SubsetOf(perms, all))
+	testspy.ExpectFail(t, observable.SubsetOf(all, perms))
+	testspy.ExpectPass(t, observable.ContainsSubstring(observable.SubsetOf(all, perms).Message(), "extra elements: [admin]"))
+
+	testspy.ExpectPass(t, observable.SupersetOf(all, perms))
+	testspy.ExpectFail(t, observable.SupersetOf(perms, all))
+	testspy.ExpectPass(t, observable.ContainsSubstring(observable.SupersetOf(perms, all).Message(), "missing elements: [admin]"))
+
+	testspy.ExpectPass(t, observable.Disjoint(perms, []string{"admin"}))
+	testspy.ExpectFail(t, observable.Disjoint(all, []string{"write", "write", "owner"}))
+	testspy.ExpectPass(t, observable.ContainsSubstring(observable.Disjoint(all, []string{"write", "write"}).Message(), "common elements: [write]"))
+}
+
+func TestUnique(t *testing.T) {
+	testspy.ExpectPass(t, observable.Unique([]int{1, 2, 3}))
+	testspy.ExpectPass(t, observable.Unique([]int(nil)))
+	testspy.ExpectFail(t, observable.Unique([]int{1, 2, 1, 3, 2, 1}))
+
+	msg := observable.Unique([]string{"a", "b", "a", "c", "b", "a"}).Message()
+	testspy.ExpectPass(t, observable.ContainsSubstring(msg, "a at indices [0 2 5]; b at indices [1 4]"))
+}
+
+func TestElementPredicates(t *testing.T) {
+	type user struct {
+		Name   string
+		Active bool
+	}
+	users := []user{{"ann", true}, {"bob", false}, {"cat", true}}
+	active := func(u user) observable.Predicate { return observable.Equal(u.Active, true) }
+	named := func(name string) func(user) observable.Predicate {
+		return func(u user) observable.Predicate { return observable.Equal(u.Name, name) }
+	}
+
+	testspy.ExpectFail(t, observable.AllElements(users, active))
+	testspy.ExpectPass(t, observable.AllElements(users[:1], active))
+	testspy.ExpectPass(t, observable.AllElements([]user{}, active))
+	testspy.ExpectPass(t, observable.ContainsSubstring(observable.AllElements(users, active).Message(), "1 failed:\n  [1]: expected true, got false"))
+
+	testspy.ExpectPass(t, observable.AnyElement(users, named("cat")))
+	testspy.ExpectFail(t, observable.AnyElement(users, named("dan")))
+	testspy.ExpectFail(t, observable.AnyElement([]user{}, named("dan")))
+	testspy.ExpectPass(t, observable.ContainsSubstring(observable.AnyElement(users, named("dan")).Message(), "[2]: expected dan, got cat"))
+}
+
+func TestContainsWhere(t *testing.T) {
+	type order struct {
+		ID     int
+		Status string
+	}
+	orders := []order{{5, "paid"}, {7, "pending"}, {8, "paid"}, {9, "refunded"}}
+
+	paid7 := func(o order) bool { return o.ID == 7 && o.Status == "paid" }
+	score := func(o order) float64 {
+		s := 0.0
+		if o.ID == 7 {
+			s += 2
+		}
+		if o.Status == "paid" {
+			s++
+		}
+		return s
+	}
+
+	testspy.ExpectPass(t, observable.ContainsWhere(orders, func(o order) bool { return o.ID == 8 }, "order 8"))
+	testspy.ExpectFail(t, observable.ContainsWhere(orders, paid7, "paid order 7"))
+	testspy.ExpectFail(t, observable.ContainsWhere(nil, paid7, "paid order 7"))
+
+	want := "expected slice of 4 elements to contain paid order 7; closest candidates:\n" +
+		"  [1]: {ID:7 Status:pending}\n" +
+		"  [0]: {ID:5 Status:paid}\n" +
+		"  [2]: {ID:8 Status:paid}"
+	if msg := observable.ContainsWhere(orders, paid7, "paid order 7", score).Message(); msg != want {
+		t.Errorf("got message\n%s\nwant\n%s", msg, want)
+	}
+
+	testspy.ExpectPass(t, observable.Panics(func() { observable.ContainsWhere(orders, paid7, "x", score, score) }))
+}
+
+func TestMonotonic(t *testing.T) {
+	testspy.ExpectPass(t, observable.Increasing([]int{1, 2, 2, 3}))
+	testspy.ExpectFail(t, observable.Increasing([]int{1, 3, 2}))
+	testspy.ExpectPass(t, observable.StrictlyIncreasing([]string{"a", "b", "c"}))
+	testspy.ExpectFail(t, observable.StrictlyIncreasing([]int{1, 2, 2}))
+	testspy.ExpectPass(t, observable.Decreasing([]float64{3, 3, 1.5}))
+	testspy.ExpectFail(t, observable.Decreasing([]float64{3, 4}))
+	testspy.ExpectPass(t, observable.StrictlyDecreasing([]uint{3, 2, 1}))
+	testspy.ExpectFail(t, observable.StrictlyDecreasing([]uint{3, 3}))
+	testspy.ExpectPass(t, observable.StrictlyIncreasing([]int(nil)))
+
+	want := "expected non-decreasing sequence, [1] = 3 is followed by [2] = 2"
+	if msg := observable.Increasing([]int{1, 3, 2, 0}).Message(); msg != want {
+		t.Errorf("got message %q, want %q", msg, want)
+	}
+}
+
+func TestCap(t *testing.T) {
+	s := make([]int, 1, 8)
+
+	testspy.ExpectPass(t, observable.Cap(s, 8))
+	testspy.ExpectFail(t, observable.Cap(s, 1))
+	testspy.ExpectPass(t, observable.Cap([]int(nil), 0))
+
+	if msg, want := observable.Cap(s, 4).Message(), "expected capacity 4, got 8 (length 1)"; msg != want {
+		t.Errorf("got message %q, want %q", msg, want)
+	}
+}
+
+func TestSequenceEqualFunc(t *testing.T) {
+	fold := func(got, want string) bool { return strings.EqualFold(got, want) }
+
+	testspy.ExpectPass(t, observable.SequenceEqualFunc([]string{"Go", "RUST"}, []string{"go", "rust"}, fold))
+	testspy.ExpectPass(t, observable.SequenceEqualFunc(nil, []string{}, fold))
+	testspy.ExpectFail(t, observable.SequenceEqualFunc([]string{"go"}, []string{"go", "rust"}, fold))
+
+	p := observable.SequenceEqualFunc([]string{"go", "zig", "c"}, []string{"go", "rust", "c"}, fold)
+	testspy.ExpectFail(t, p)
+	testspy.ExpectPass(t, observable.ContainsSubstring(p.Message(), "first difference at index 1: got zig, want rust"))
+
+	p = observable.SequenceEqualFunc([]string{"go"}, []string{"go", "rust"}, fold)
+	testspy.ExpectPass(t, observable.ContainsSubstring(p.Message(), "length 1, want 2"))
+
+	testspy.ExpectPass(t, observable.Panics(func() { observable.SequenceEqualFunc([]int{1}, []int{1}, nil) }))
+}
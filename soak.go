@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// SoakOption configures [Soak].
+type SoakOption func(*soakConfig)
+
+type soakConfig struct {
+	maxFailureRate float64
+	short          time.Duration
+}
+
+// soakReportFailures is the number of failing iterations whose messages [Soak] reports.
+const soakReportFailures = 5
+
+// MaxFailureRate makes [Soak] tolerate failing iterations as long as they make up at most rate (between 0 and 1) of all iterations. The default is 0: any failing iteration fails the soak.
+func MaxFailureRate(rate float64) SoakOption {
+	return func(c *soakConfig) { c.maxFailureRate = rate }
+}
+
+// ShortSoak sets how long [Soak] runs at most when tests run with -short. The default is one second.
+func ShortSoak(d time.Duration) SoakOption {
+	return func(c *soakConfig) { c.short = d }
+}
+
+// Soak runs iteration with i = 0, 1, 2, ... until duration has elapsed, evaluating each returned predicate, and asserts that the fraction of failing iterations is within [MaxFailureRate]. The run stops early under -short (see [ShortSoak]) and before tb's deadline (see [DeadlineContext]). The iteration latency percentiles are logged; on failure they are reported together with the first failing iterations' messages. With [RecoverPanics] set, an iteration that panics counts as failing and its panic is reported with its index. As with [Assert], tb may be nil, in which case Soak **panics** with the failure message on failure and logs nothing.
+func Soak(tb testing.TB, duration time.Duration, iteration func(i int) Predicate, opts ...SoakOption) bool {
+	if !nilTB(tb) {
+		tb.Helper()
+	}
+
+	cfg := soakConfig{short: time.Second}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if testing.Short() && duration > cfg.short {
+		duration = cfg.short
+	}
+
+	ctx := context.Background()
+	if !nilTB(tb) {
+		ctx = DeadlineContext(tb)
+	}
+	stop := time.Now().Add(duration)
+
+	var (
+		latencies = latencySample{rand: rand.New(rand.NewSource(1))}
+		failures  []string
+		failed    int
+	)
+	for i := 0; time.Now().Before(stop) && ctx.Err() == nil; i++ {
+		start := time.Now()
+		p := soakStep(iteration, i)
+		ok := p.Ok()
+		latencies.add(time.Since(start))
+
+		if !ok {
+			failed++
+			if len(failures) < soakReportFailures {
+				failures = append(failures, fmt.Sprintf("[%d]: %s", i, p.Message()))
+			}
+		}
+	}
+
+	n := latencies.n
+	stats := latencies.String()
+	if !nilTB(tb) {
+		tb.Logf("soak: %d iterations, %d failed; latency %s", n, failed, stats)
+	}
+
+	rate := 0.0
+	if n > 0 {
+		rate = float64(failed) / float64(n)
+	}
+
+	return Assert(tb, Predicate{
+		ok: func() bool { return n > 0 && rate <= cfg.maxFailureRate },
+		msg: func() string {
+			if n == 0 {
+				return sprintf("expected soak to run at least one iteration within %v", duration)
+			}
+			return sprintf("expected failure rate at most %.2f%%, %d of %d iterations failed (%.2f%%); latency %s\nfirst failures:\n  %s",
+				100*cfg.maxFailureRate, failed, n, 100*rate, stats, strings.Join(failures, "\n  "))
+		},
+		desc: func() string { return fmt.Sprintf("soak for %v", duration) },
+	})
+}
+
+// soakStep returns a predicate that builds and evaluates the predicate of iteration i, so that with [RecoverPanics] set a panic in either fails only that iteration.
+func soakStep(iteration func(i int) Predicate, i int) Predicate {
+	var p Predicate
+
+	return recoverIfEnabled(Predicate{
+		ok:  memo(func() bool { p = iteration(i); return p.Ok() }),
+		msg: func() string { return p.Message() },
+	})
+}
+
+// latencySampleSize bounds the number of latencies kept by [latencySample], so that long soaks run in constant memory.
+const latencySampleSize = 10000
+
+// latencySample keeps a uniform random sample of the latencies added to it (reservoir sampling), from which percentiles are estimated, and the exact maximum.
+type latencySample struct {
+	rand   *rand.Rand
+	n      int
+	max    time.Duration
+	sample []time.Duration
+}
+
+func (s *latencySample) add(d time.Duration) {
+	s.n++
+	if d > s.max {
+		s.max = d
+	}
+
+	if len(s.sample) < latencySampleSize {
+		s.sample = append(s.sample, d)
+	} else if i := s.rand.Intn(s.n); i < latencySampleSize {
+		s.sample[i] = d
+	}
+}
+
+// String summarizes the latencies as percentiles, e.g. "p50=1ms p90=2ms p99=5ms max=9ms".
+func (s *latencySample) String() string {
+	if s.n == 0 {
+		return "n/a"
+	}
+
+	sorted := append([]time.Duration(nil), s.sample...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	pct := func(p float64) time.Duration { return sorted[int(p*float64(len(sorted)-1))] }
+
+	return fmt.Sprintf("p50=%v p90=%v p99=%v max=%v", pct(0.5), pct(0.9), pct(0.99), s.max)
+}
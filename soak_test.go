@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestSoak(t *testing.T) {
+	iterations := 0
+	if !observable.Soak(t, 50*time.Millisecond, func(i int) observable.Predicate {
+		iterations++
+		return observable.True()
+	}) {
+		t.Error("expected soak to pass")
+	}
+	if iterations == 0 {
+		t.Error("expected iterations to run")
+	}
+
+	flaky := func(i int) observable.Predicate { return observable.That(i%10 != 3) }
+
+	spy := testspy.New(t)
+	if observable.Soak(spy, 20*time.Millisecond, flaky) {
+		t.Error("expected soak with failures to fail")
+	}
+	testspy.ExpectPass(t, observable.HasPrefix(spy.Messages[0], "expected failure rate at most 0.00%, "))
+	testspy.ExpectPass(t, observable.ContainsSubstring(spy.Messages[0], "first failures:\n  [3]: expected true, got false\n  [13]: expected true, got false"))
+	testspy.ExpectPass(t, observable.ContainsSubstring(spy.Logs[0], "latency p50="))
+
+	spy = testspy.New(t)
+	if !observable.Soak(spy, 20*time.Millisecond, flaky, observable.MaxFailureRate(0.2)) {
+		t.Errorf("expected soak within failure budget to pass, got %q", spy.Messages)
+	}
+
+	if testing.Short() {
+		start := time.Now()
+		observable.Soak(t, time.Hour, func(int) observable.Predicate { return observable.True() }, observable.ShortSoak(10*time.Millisecond))
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected -short to cap the soak duration, ran for %v", elapsed)
+		}
+	}
+}
+
+func TestSoakRecoversPanics(t *testing.T) {
+	defer func(v bool) { observable.RecoverPanics = v }(observable.RecoverPanics)
+	observable.RecoverPanics = true
+
+	spy := testspy.New(t)
+	if observable.Soak(spy, 20*time.Millisecond, func(i int) observable.Predicate {
+		if i == 2 {
+			panic("boom")
+		}
+		return observable.True()
+	}) {
+		t.Error("expected soak with a panicking iteration to fail")
+	}
+	testspy.ExpectPass(t, observable.ContainsSubstring(spy.Messages[0], "first failures:\n  [2]: predicate panicked: boom\n"))
+}
+
+func TestSoakNilTB(t *testing.T) {
+	if !observable.Soak(nil, 5*time.Millisecond, func(int) observable.Predicate { return observable.True() }) {
+		t.Error("expected nil-TB soak to pass")
+	}
+
+	defer func() {
+		if r, _ := recover().(string); !strings.HasPrefix(r, "expected failure rate at most 0.00%, ") {
+			t.Errorf("got panic %q", r)
+		}
+	}()
+	observable.Soak((*testing.T)(nil), 5*time.Millisecond, func(int) observable.Predicate { return observable.False() })
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StableJSON returns v serialized as indented JSON in a deterministic form suitable for golden files (see [MatchesGolden]): object keys are sorted, integers are written without a fraction or exponent and other numbers in their shortest form, so that 1.0, 1 and 1e0 all serialize as 1. v is first marshaled with [json.Marshal], so its MarshalJSON methods and struct tags apply; StableJSON **panics** if that fails.
+func StableJSON(v any) []byte {
+	doc := stableValue(v)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		panic(fmt.Sprintf("StableJSON: %v", err))
+	}
+
+	return buf.Bytes()
+}
+
+// StableYAML returns v serialized as block-style YAML in the same deterministic form as [StableJSON]: v is marshaled as JSON, so JSON struct tags apply, and mapping keys are sorted. Strings are quoted only when they would otherwise read as another type or as YAML syntax. StableYAML **panics** if v cannot be marshaled.
+func StableYAML(v any) []byte {
+	var sb strings.Builder
+	writeYAML(&sb, stableValue(v), 0)
+	return []byte(sb.String())
+}
+
+// stableValue round-trips v through JSON into maps, slices and scalars, with numbers normalized as described for [StableJSON].
+func stableValue(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("stable serialization: %v", err))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		panic(fmt.Sprintf("stable serialization: %v", err))
+	}
+
+	return normalizeNumbers(doc)
+}
+
+// normalizeNumbers rewrites the numbers in doc in their canonical form.
+func normalizeNumbers(doc any) any {
+	switch v := doc.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = normalizeNumbers(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = normalizeNumbers(e)
+		}
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return json.Number(strconv.FormatInt(i, 10))
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return v
+		}
+		if f == float64(int64(f)) && f >= -1<<63 && f < 1<<63 {
+			return json.Number(strconv.FormatInt(int64(f), 10))
+		}
+		b, _ := json.Marshal(f)
+		return json.Number(b)
+	}
+	return doc
+}
+
+// writeYAML writes v as YAML at the given indentation. Mappings and sequences end with a newline; scalars do not.
+func writeYAML(sb *strings.Builder, v any, indent int) {
+	pad := strings.Repeat(" ", indent)
+
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			sb.WriteString("{}\n")
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for i, k := range keys {
+			if i > 0 {
+				sb.WriteString(pad)
+			}
+			sb.WriteString(yamlString(k) + ":")
+			writeYAMLChild(sb, v[k], indent)
+		}
+	case []any:
+		if len(v) == 0 {
+			sb.WriteString("[]\n")
+			return
+		}
+		for i, e := range v {
+			if i > 0 {
+				sb.WriteString(pad)
+			}
+			sb.WriteString("- ")
+			writeYAML(sb, e, indent+2)
+			if !isYAMLCollection(e) {
+				sb.WriteString("\n")
+			}
+		}
+	case string:
+		sb.WriteString(yamlString(v))
+	case nil:
+		sb.WriteString("null")
+	default:
+		fmt.Fprint(sb, v)
+	}
+}
+
+// writeYAMLChild writes the value of a mapping entry whose key has just been written.
+func writeYAMLChild(sb *strings.Builder, v any, indent int) {
+	switch c := v.(type) {
+	case map[string]any:
+		if len(c) > 0 {
+			sb.WriteString("\n" + strings.Repeat(" ", indent+2))
+			writeYAML(sb, v, indent+2)
+			return
+		}
+	case []any:
+		if len(c) > 0 {
+			sb.WriteString("\n" + strings.Repeat(" ", indent+2))
+			writeYAML(sb, v, indent+2)
+			return
+		}
+	}
+
+	sb.WriteString(" ")
+	writeYAML(sb, v, indent)
+	if !isYAMLCollection(v) {
+		sb.WriteString("\n")
+	}
+}
+
+// isYAMLCollection reports whether v is written as a mapping or sequence, which end with their own newline.
+func isYAMLCollection(v any) bool {
+	switch v.(type) {
+	case map[string]any, []any:
+		return true
+	}
+	return false
+}
+
+// yamlString renders s as a plain scalar when that reads back as the same string, and double-quoted otherwise.
+func yamlString(s string) string {
+	plain := s != "" && s == strings.TrimSpace(s) &&
+		!strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") &&
+		!strings.ContainsAny(s, "\n\t\\\"") &&
+		!strings.Contains(s, ": ") && !strings.Contains(s, " #") && !strings.HasSuffix(s, ":") &&
+		resolveYAMLScalar(s) == any(s)
+	if plain {
+		return s
+	}
+	return strconv.Quote(s)
+}
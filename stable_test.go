@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+type snapshot struct {
+	Name   string             `json:"name"`
+	Scores map[string]float64 `json:"scores"`
+	Tags   []string           `json:"tags"`
+	Items  []map[string]any   `json:"items"`
+	Note   *string            `json:"note"`
+}
+
+var snap = snapshot{
+	Name:   "a <b>",
+	Scores: map[string]float64{"z": 1.0, "a": 0.5, "m": 1e21},
+	Tags:   []string{"x", "true", "-y", ""},
+	Items:  []map[string]any{{"id": 2, "sub": []any{1, []any{}}}, {}},
+}
+
+func TestStableJSON(t *testing.T) {
+	want := `{
+  "items": [
+    {
+      "id": 2,
+      "sub": [
+        1,
+        []
+      ]
+    },
+    {}
+  ],
+  "name": "a <b>",
+  "note": null,
+  "scores": {
+    "a": 0.5,
+    "m": 1e+21,
+    "z": 1
+  },
+  "tags": [
+    "x",
+    "true",
+    "-y",
+    ""
+  ]
+}
+`
+	testspy.ExpectPass(t, observable.StringEqual(string(observable.StableJSON(snap)), want))
+	testspy.ExpectPass(t, observable.StringEqual(string(observable.StableJSON(map[string]any{"f": 1.25e-7})), "{\n  \"f\": 1.25e-7\n}\n"))
+	testspy.ExpectPass(t, observable.Panics(func() { observable.StableJSON(func() {}) }))
+}
+
+func TestStableYAML(t *testing.T) {
+	want := `items:
+  - id: 2
+    sub:
+      - 1
+      - []
+  - {}
+name: a <b>
+note: null
+scores:
+  a: 0.5
+  m: 1e+21
+  z: 1
+tags:
+  - x
+  - "true"
+  - "-y"
+  - ""
+`
+	got := string(observable.StableYAML(snap))
+	testspy.ExpectPass(t, observable.StringEqual(got, want))
+	testspy.ExpectPass(t, observable.YAMLEqual(got, want))
+	testspy.ExpectPass(t, observable.StringEqual(string(observable.StableYAML([]any{[]any{1, 2}, "a: b"})), "- - 1\n  - 2\n- \"a: b\"\n"))
+	testspy.ExpectPass(t, observable.StringEqual(string(observable.StableYAML(3)), "3"))
+}
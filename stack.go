@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// StackFilter controls which frames appear in stack traces included in failure messages, such as those reported for recovered panics.
+type StackFilter struct {
+	// Exclude lists function name prefixes (e.g. "runtime.") whose frames are omitted.
+	Exclude []string
+	// Include lists function name prefixes whose frames are always kept, even if they match Exclude.
+	Include []string
+}
+
+// DefaultStackFilter omits frames from the runtime, the testing package and this package.
+var DefaultStackFilter = StackFilter{
+	Exclude: []string{"runtime.", "testing.", "renorm.dev/observable."},
+}
+
+var (
+	stackFilterMu sync.RWMutex
+	stackFilter   = DefaultStackFilter
+)
+
+// SetStackFilter sets the filter applied to stack traces in failure messages. Use [DefaultStackFilter] to restore the default, or the zero StackFilter to keep every frame.
+func SetStackFilter(f StackFilter) {
+	stackFilterMu.Lock()
+	defer stackFilterMu.Unlock()
+
+	stackFilter = f
+}
+
+// keep reports whether the frame for function should be shown.
+func (f StackFilter) keep(function string) bool {
+	for _, prefix := range f.Include {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	for _, prefix := range f.Exclude {
+		if strings.HasPrefix(function, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filterGoroutines applies the configured [StackFilter] to a dump of all goroutines in the format of [runtime.Stack]. Goroutines left without frames are omitted, and their number is noted at the end.
+func filterGoroutines(dump string) string {
+	stackFilterMu.RLock()
+	filter := stackFilter
+	stackFilterMu.RUnlock()
+
+	var (
+		blocks  []string
+		omitted int
+	)
+	for _, block := range strings.Split(strings.TrimSpace(dump), "\n\n") {
+		lines := strings.Split(block, "\n")
+		kept, frames := []string{lines[0]}, 0
+
+		// After the "goroutine N [state]:" header, each frame is a function line followed by a tab-indented location line.
+		keep := false
+		for _, line := range lines[1:] {
+			if !strings.HasPrefix(line, "\t") {
+				if keep = filter.keep(stackFunction(line)); keep {
+					frames++
+				}
+			}
+			if keep {
+				kept = append(kept, line)
+			}
+		}
+
+		if frames == 0 {
+			omitted++
+			continue
+		}
+		blocks = append(blocks, strings.Join(kept, "\n"))
+	}
+
+	if omitted > 0 {
+		blocks = append(blocks, sprintf("(%d goroutines with only filtered frames omitted)", omitted))
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// stackFunction extracts the function name from a function line of a [runtime.Stack] dump, such as "main.(*T).Run(0xc000010000)" or "created by main.main in goroutine 1".
+func stackFunction(line string) string {
+	if strings.HasPrefix(line, "created by ") {
+		name := strings.TrimPrefix(line, "created by ")
+		if i := strings.Index(name, " in goroutine "); i >= 0 {
+			name = name[:i]
+		}
+		return name
+	}
+	if i := strings.LastIndex(line, "("); i > 0 && strings.HasSuffix(line, ")") {
+		return line[:i]
+	}
+
+	return line
+}
+
+// stackTrace renders the current goroutine's stack, filtered by the configured [StackFilter]. skip is the number of frames to omit, as for [runtime.Callers], relative to stackTrace's caller.
+func stackTrace(skip int) string {
+	stackFilterMu.RLock()
+	filter := stackFilter
+	stackFilterMu.RUnlock()
+
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(skip+2, pcs)])
+
+	var sb strings.Builder
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && filter.keep(frame.Function) {
+			fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
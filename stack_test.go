@@ -0,0 +1,32 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestStackFilter(t *testing.T) {
+	defer observable.SetStackFilter(observable.DefaultStackFilter)
+
+	msg := observable.Recover(panicking()).Message()
+	testspy.ExpectPass(t, observable.ContainsSubstring(msg, "renorm.dev/observable_test.panicking"))
+	testspy.ExpectFail(t, observable.ContainsSubstring(msg, "runtime.gopanic"))
+	testspy.ExpectFail(t, observable.ContainsSubstring(msg, "testing.tRunner"))
+
+	observable.SetStackFilter(observable.StackFilter{})
+	msg = observable.Recover(panicking()).Message()
+	testspy.ExpectPass(t, observable.ContainsSubstring(msg, "runtime.gopanic"))
+	testspy.ExpectPass(t, observable.ContainsSubstring(msg, "testing.tRunner"))
+
+	observable.SetStackFilter(observable.StackFilter{
+		Exclude: []string{"runtime.", "testing.", "renorm.dev/"},
+		Include: []string{"renorm.dev/observable_test.TestStackFilter"},
+	})
+	msg = observable.Recover(panicking()).Message()
+	testspy.ExpectPass(t, observable.ContainsSubstring(msg, "renorm.dev/observable_test.TestStackFilter"))
+	testspy.ExpectFail(t, observable.ContainsSubstring(msg, "renorm.dev/observable_test.panicking"))
+}
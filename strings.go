@@ -3,8 +3,11 @@
 package observable
 
 import (
+	"errors"
 	"fmt"
+	"path"
 	"regexp"
+	"regexp/syntax"
 	"strings"
 	"sync"
 	"unicode/utf8"
@@ -13,8 +16,9 @@ import (
 // StringLength returns a [Predicate] that succeeds when len(s) == want.
 func StringLength(s string, want int) Predicate {
 	return Predicate{
-		ok:  func() bool { return len(s) == want },
-		msg: func() string { return fmt.Sprintf("expected length %d, got %d", want, len(s)) },
+		ok:   memo(func() bool { return len(s) == want }),
+		msg:  func() string { return sprintf("expected length %d, got %d", want, len(s)) },
+		desc: func() string { return fmt.Sprintf("len == %d", want) },
 	}
 }
 
@@ -26,63 +30,211 @@ func EmptyString(s string) Predicate {
 // RuneLength returns a [Predicate] that succeeds when utf8.RuneCountInString(s) == want.
 func RuneLength(s string, want int) Predicate {
 	return Predicate{
-		ok:  func() bool { return utf8.RuneCountInString(s) == want },
-		msg: func() string { return fmt.Sprintf("expected rune length %d, got %d", want, utf8.RuneCountInString(s)) },
+		ok:   memo(func() bool { return utf8.RuneCountInString(s) == want }),
+		msg:  func() string { return sprintf("expected rune length %d, got %d", want, utf8.RuneCountInString(s)) },
+		desc: func() string { return fmt.Sprintf("rune len == %d", want) },
 	}
 }
 
 // HasPrefix returns a [Predicate] that succeeds when strings.HasPrefix(s, prefix).
 func HasPrefix(s, prefix string) Predicate {
 	return Predicate{
-		ok:  func() bool { return strings.HasPrefix(s, prefix) },
-		msg: func() string { return fmt.Sprintf("expected %q to have prefix %q", s, prefix) },
+		ok:   memo(func() bool { return strings.HasPrefix(s, prefix) }),
+		msg:  func() string { return sprintf("expected %q to have prefix %q", s, prefix) },
+		desc: func() string { return fmt.Sprintf("has prefix %q", prefix) },
 	}
 }
 
 // HasSuffix returns a [Predicate] that succeeds when strings.HasSuffix(s, suffix).
 func HasSuffix(s, suffix string) Predicate {
 	return Predicate{
-		ok:  func() bool { return strings.HasSuffix(s, suffix) },
-		msg: func() string { return fmt.Sprintf("expected %q to have suffix %q", s, suffix) },
+		ok:   memo(func() bool { return strings.HasSuffix(s, suffix) }),
+		msg:  func() string { return sprintf("expected %q to have suffix %q", s, suffix) },
+		desc: func() string { return fmt.Sprintf("has suffix %q", suffix) },
 	}
 }
 
 // ContainsSubstring returns a [Predicate] that succeeds when strings.Contains(s, substr).
 func ContainsSubstring(s, substr string) Predicate {
 	return Predicate{
-		ok:  func() bool { return strings.Contains(s, substr) },
-		msg: func() string { return fmt.Sprintf("expected %q to contain %q", s, substr) },
+		ok:   memo(func() bool { return strings.Contains(s, substr) }),
+		msg:  func() string { return sprintf("expected %q to contain %q", s, substr) },
+		neg:  func() string { return sprintf("expected %q not to contain %q", s, substr) },
+		desc: func() string { return fmt.Sprintf("contains %q", substr) },
 	}
 }
 
-// EqualFold returns a [Predicate] that succeeds when strings.EqualFold(got, want) (case-insensitive).
-func EqualFold(got, want string) Predicate {
+// ContainsAllSubstrings returns a [Predicate] that succeeds when s contains every one of subs. The failure message lists exactly the substrings that are missing.
+func ContainsAllSubstrings(s string, subs ...string) Predicate {
+	var (
+		once    sync.Once
+		missing []string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			for _, sub := range subs {
+				if !strings.Contains(s, sub) {
+					missing = append(missing, sub)
+				}
+			}
+		})
+	}
+
 	return Predicate{
-		ok:  func() bool { return strings.EqualFold(got, want) },
-		msg: func() string { return fmt.Sprintf("expected %q (case-insensitive), got %q", want, got) },
+		ok: func() bool { eval(); return len(missing) == 0 },
+		msg: func() string {
+			eval()
+			return sprintf("expected %q to contain all of %q, missing %q", s, subs, missing)
+		},
+		desc: func() string { return fmt.Sprintf("contains all of %q", subs) },
 	}
 }
 
-// RegexpMatches returns a [Predicate] that succeeds when the regular expression re matches s. The regular expression can either be a [*regexp.Regexp] or a string which will be compiled with [regexp.MustCompile].
-func RegexpMatches[T string | *regexp.Regexp](s string, reOrString T) Predicate {
+// ContainsAnySubstring returns a [Predicate] that succeeds when s contains at least one of subs. With no subs it never succeeds. When negated, the failure message lists the substrings that were found.
+func ContainsAnySubstring(s string, subs ...string) Predicate {
 	var (
-		once sync.Once
-		re   *regexp.Regexp
+		once  sync.Once
+		found []string
 	)
 
 	eval := func() {
 		once.Do(func() {
-			switch x := any(reOrString).(type) {
-			case *regexp.Regexp:
-				re = x
-			case string:
-				re = regexp.MustCompile(x)
+			for _, sub := range subs {
+				if strings.Contains(s, sub) {
+					found = append(found, sub)
+				}
 			}
 		})
 	}
 
 	return Predicate{
-		ok:  func() bool { eval(); return re.MatchString(s) },
-		msg: func() string { eval(); return fmt.Sprintf("expected %q to match %q", s, re.String()) },
+		ok:   func() bool { eval(); return len(found) > 0 },
+		msg:  func() string { return sprintf("expected %q to contain any of %q, found none", s, subs) },
+		neg:  func() string { eval(); return sprintf("expected %q to contain none of %q, found %q", s, subs, found) },
+		desc: func() string { return fmt.Sprintf("contains any of %q", subs) },
+	}
+}
+
+// StringEqual returns a [Predicate] that succeeds when got == want. When either string spans several lines, the failure message is a line-by-line diff with line numbers rather than both strings in full.
+func StringEqual(got, want string) Predicate {
+	return Predicate{
+		ok: memo(func() bool { return got == want }),
+		msg: func() string {
+			if !strings.Contains(got, "\n") && !strings.Contains(want, "\n") {
+				return sprintf("expected %q, got %q", want, got)
+			}
+			return sprintf("expected strings to be equal\n%s", lineDiff(want, got))
+		},
+		neg:    func() string { return sprintf("expected string other than %q", want) },
+		desc:   func() string { return fmt.Sprintf("== %q", want) },
+		values: func() (any, any) { return got, want },
+	}
+}
+
+// EqualFold returns a [Predicate] that succeeds when strings.EqualFold(got, want) (case-insensitive).
+func EqualFold(got, want string) Predicate {
+	return Predicate{
+		ok:     memo(func() bool { return strings.EqualFold(got, want) }),
+		msg:    func() string { return sprintf("expected %q (case-insensitive), got %q", want, got) },
+		desc:   func() string { return fmt.Sprintf("equal fold %q", want) },
+		values: func() (any, any) { return got, want },
+	}
+}
+
+// RegexpMatches returns a [Predicate] that succeeds when the regular expression re matches s. The regular expression can either be a [*regexp.Regexp] or a string, which is compiled when the predicate is built; a malformed pattern makes the predicate fail with the compile error, and so does a nil *regexp.Regexp; the negation of such a predicate fails too. On failure the message shows the longest leading part of the pattern that matches in s, what it matched and the offset at which matching diverged.
+func RegexpMatches[T string | *regexp.Regexp](s string, reOrString T) Predicate {
+	var (
+		re      *regexp.Regexp
+		pattern string
+		err     error
+	)
+
+	switch x := any(reOrString).(type) {
+	case *regexp.Regexp:
+		if re = x; re == nil {
+			err = errors.New("nil *regexp.Regexp")
+		} else {
+			pattern = re.String()
+		}
+	case string:
+		pattern = x
+		re, err = regexp.Compile(x)
+	}
+
+	return Predicate{
+		ok:  memo(func() bool { return err == nil && re.MatchString(s) }),
+		err: func() error { return err },
+		msg: func() string {
+			if err != nil {
+				return sprintf("expected %q to match %q: invalid pattern: %v", s, pattern, err)
+			}
+			return sprintf("expected %q to match %q", s, pattern) + regexpDivergence(s, re)
+		},
+		neg:  func() string { return sprintf("expected %q not to match %q", s, pattern) },
+		desc: func() string { return fmt.Sprintf("matches %v", pattern) },
+	}
+}
+
+// regexpDivergence explains why re does not match s by finding the longest leading run of the top-level elements of re that matches somewhere in s. It returns "" when re cannot be analysed.
+func regexpDivergence(s string, re *regexp.Regexp) string {
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return ""
+	}
+
+	elems := []*syntax.Regexp{parsed}
+	if parsed.Op == syntax.OpConcat {
+		elems = parsed.Sub
+	}
+	concat := func(elems []*syntax.Regexp) string {
+		return (&syntax.Regexp{Op: syntax.OpConcat, Sub: elems}).String()
+	}
+
+	for i := len(elems) - 1; i > 0; i-- {
+		partial, err := regexp.Compile(concat(elems[:i]))
+		if err != nil {
+			return ""
+		}
+
+		// Of all matches, the one reaching furthest into s is the most informative.
+		loc := []int(nil)
+		for _, m := range partial.FindAllStringIndex(s, -1) {
+			if loc == nil || m[1] > loc[1] {
+				loc = m
+			}
+		}
+		if loc == nil {
+			continue
+		}
+
+		return sprintf("\n  matched:  %q by %q\n  diverged: at byte offset %d (%s), expecting %q", s[loc[0]:loc[1]], partial.String(), loc[1], excerpt(s, loc[1]), concat(elems[i:]))
+	}
+
+	return sprintf("\n  diverged: no match for %q", elems[0].String())
+}
+
+// MatchesGlob returns a [Predicate] that succeeds when s matches the shell pattern pattern, using the syntax of [path.Match]: "*" matches any run of characters other than "/", "?" a single such character, and "[...]" a character class. A malformed pattern makes the predicate, and its negation, fail with the pattern error.
+func MatchesGlob(s, pattern string) Predicate {
+	var (
+		once    sync.Once
+		matched bool
+		err     error
+	)
+
+	eval := func() { once.Do(func() { matched, err = path.Match(pattern, s) }) }
+
+	return Predicate{
+		ok:  func() bool { eval(); return err == nil && matched },
+		err: func() error { eval(); return err },
+		msg: func() string {
+			eval()
+			if err != nil {
+				return sprintf("expected %q to match glob %q: %v", s, pattern, err)
+			}
+			return sprintf("expected %q to match glob %q", s, pattern)
+		},
+		neg:  func() string { return sprintf("expected %q not to match glob %q", s, pattern) },
+		desc: func() string { return fmt.Sprintf("matches glob %q", pattern) },
 	}
 }
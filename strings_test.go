@@ -4,6 +4,7 @@ package observable_test
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"renorm.dev/observable"
@@ -36,3 +37,83 @@ func TestStringAsserts(t *testing.T) {
 	re := regexp.MustCompile(`[a-z]\d\d\d[a-z]`)
 	testspy.ExpectPass(t, observable.RegexpMatches("d123b", re))
 }
+
+func TestRegexpMatchesDivergence(t *testing.T) {
+	p := observable.RegexpMatches("user=bob id=7", `user=\w+ id=[a-z]+`)
+	testspy.ExpectFail(t, p)
+	testspy.ExpectPass(t, observable.ContainsSubstring(p.Message(), `matched:  "user=bob id=" by "user=[0-9A-Z_a-z]+ id="`))
+	testspy.ExpectPass(t, observable.ContainsSubstring(p.Message(), `diverged: at byte offset 12 (user=bob id=▶7), expecting "[a-z]+"`))
+
+	p = observable.RegexpMatches("abc", regexp.MustCompile(`x+`))
+	testspy.ExpectFail(t, p)
+	testspy.ExpectPass(t, observable.ContainsSubstring(p.Message(), `diverged: no match for "x+"`))
+
+	testspy.ExpectFail(t, observable.Not(observable.RegexpMatches("abc", `b`)))
+}
+
+func TestRegexpMatchesInvalid(t *testing.T) {
+	var p observable.Predicate
+	testspy.ExpectFail(t, observable.Panics(func() { p = observable.RegexpMatches("abc", `(`) }))
+	testspy.ExpectFail(t, p)
+	testspy.ExpectPass(t, observable.HasPrefix(p.Message(), `expected "abc" to match "(": invalid pattern: `))
+
+	negated := observable.Not(p)
+	testspy.ExpectFail(t, negated)
+	testspy.ExpectPass(t, observable.Equal(negated.Message(), p.Message()))
+	testspy.ExpectFail(t, observable.Not(negated))
+
+	var nilRE *regexp.Regexp
+	testspy.ExpectFail(t, observable.RegexpMatches("abc", nilRE))
+	testspy.ExpectFail(t, observable.Not(observable.RegexpMatches("abc", nilRE)))
+	testspy.ExpectPass(t, observable.HasSuffix(observable.Not(observable.RegexpMatches("abc", nilRE)).Message(), "invalid pattern: nil *regexp.Regexp"))
+}
+
+func TestStringEqual(t *testing.T) {
+	testspy.ExpectPass(t, observable.StringEqual("a\nb", "a\nb"))
+	testspy.ExpectFail(t, observable.StringEqual("a", "b"))
+
+	if msg := observable.StringEqual("one", "two").Message(); msg != `expected "two", got "one"` {
+		t.Errorf("unexpected message: %q", msg)
+	}
+
+	got := "SELECT id\nFROM users\nWHERE active\nORDER BY id"
+	want := "SELECT id\nFROM users\nWHERE deleted\nORDER BY id"
+	wantMsg := "expected strings to be equal\n--- want\n+++ got\n    1: SELECT id\n    2: FROM users\n+   3: WHERE active\n-   3: WHERE deleted\n    4: ORDER BY id"
+	if msg := observable.StringEqual(got, want).Message(); msg != wantMsg {
+		t.Errorf("got message\n%s\nwant\n%s", msg, wantMsg)
+	}
+}
+
+func TestContainsSubstrings(t *testing.T) {
+	log := "level=info msg=started port=8080"
+
+	testspy.ExpectPass(t, observable.ContainsAllSubstrings(log, "started", "port=8080"))
+	testspy.ExpectPass(t, observable.ContainsAllSubstrings(log))
+	testspy.ExpectFail(t, observable.ContainsAllSubstrings(log, "started", "level=debug", "tls=on"))
+
+	if msg := observable.ContainsAllSubstrings(log, "started", "level=debug", "tls=on").Message(); !strings.HasSuffix(msg, `missing ["level=debug" "tls=on"]`) {
+		t.Errorf("unexpected message: %q", msg)
+	}
+
+	testspy.ExpectPass(t, observable.ContainsAnySubstring(log, "error", "started"))
+	testspy.ExpectFail(t, observable.ContainsAnySubstring(log, "error", "panic"))
+	testspy.ExpectFail(t, observable.ContainsAnySubstring(log))
+
+	if msg := observable.Not(observable.ContainsAnySubstring(log, "error", "info")).Message(); !strings.HasSuffix(msg, `found ["info"]`) {
+		t.Errorf("unexpected message: %q", msg)
+	}
+}
+
+func TestMatchesGlob(t *testing.T) {
+	testspy.ExpectPass(t, observable.MatchesGlob("user-42-prod", "user-*-prod"))
+	testspy.ExpectPass(t, observable.MatchesGlob("v1.2", "v?.[0-9]"))
+	testspy.ExpectFail(t, observable.MatchesGlob("user-42-staging", "user-*-prod"))
+	testspy.ExpectFail(t, observable.MatchesGlob("a/b", "*"))
+	testspy.ExpectFail(t, observable.MatchesGlob("a", "[a"))
+	testspy.ExpectFail(t, observable.Not(observable.MatchesGlob("a", "[a")))
+	testspy.ExpectFail(t, observable.Not(observable.MatchesGlob("b", "[a")))
+
+	if msg := observable.MatchesGlob("a", "[a").Message(); !strings.HasSuffix(msg, "syntax error in pattern") {
+		t.Errorf("unexpected message: %q", msg)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// subprocessEnv names the environment variable telling a re-executed test binary which subprocess to run.
+const subprocessEnv = "OBSERVABLE_SUBPROCESS"
+
+// subprocessResultsEnv names the environment variable holding the path of a file with the results of the InSubprocess calls the parent made before the one the child runs.
+const subprocessResultsEnv = "OBSERVABLE_SUBPROCESS_RESULTS"
+
+var (
+	subprocessMu sync.Mutex
+	// subprocessResults holds, per test name, the results of the InSubprocess calls made so far, keyed by subprocess key.
+	subprocessResults = map[string]map[string]*Subprocess{}
+)
+
+// Subprocess is the outcome of running a function with [InSubprocess].
+type Subprocess struct {
+	// Name is the name passed to InSubprocess.
+	Name string
+	// ExitCode is the child's exit code, which is 0 when f returns normally.
+	ExitCode int
+	// Stdout and Stderr hold everything the child wrote to its standard output and error.
+	Stdout, Stderr string
+}
+
+// InSubprocess runs f in a child process and returns the child's exit code and output, for testing code paths that call [os.Exit] or log.Fatal. The child is a re-execution of the test binary that runs only the current test, with an environment variable directing the call to InSubprocess named name to run f and exit with code 0 when it returns.
+//
+// The current test therefore runs again in the child up to the InSubprocess call, so code before it should be free of side effects outside the process. Earlier InSubprocess calls of the test are not rerun in the child: they return the results the parent got, so assertions on them behave as in the parent, and a call the parent did not make skips the rest of the test. Tests using InSubprocess must not be run with t.Parallel() alongside tests that modify the environment. Failing to start the child stops the test with Fatal.
+func InSubprocess(t *testing.T, name string, f func()) *Subprocess {
+	t.Helper()
+
+	key := t.Name() + "#" + name
+	switch os.Getenv(subprocessEnv) {
+	case key:
+		f()
+		os.Exit(0)
+	case "":
+	default:
+		// This is the child of a later InSubprocess call in the same test; replay what the parent got here.
+		if s, ok := replaySubprocess(key); ok {
+			return s
+		}
+		t.Skipf("InSubprocess %q: not reached by the parent test", name)
+	}
+
+	levels := strings.Split(t.Name(), "/")
+	for i, level := range levels {
+		levels[i] = "^" + regexp.QuoteMeta(level) + "$"
+	}
+
+	results, err := recordSubprocesses(t)
+	if err != nil {
+		t.Fatalf("InSubprocess %q: %v", name, err)
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run="+strings.Join(levels, "/"), "-test.count=1")
+	cmd.Env = append(os.Environ(), subprocessEnv+"="+key, subprocessResultsEnv+"="+results)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	s := &Subprocess{Name: name}
+	if err := cmd.Run(); err != nil {
+		var exit *exec.ExitError
+		if !errors.As(err, &exit) {
+			t.Fatalf("InSubprocess %q: %v", name, err)
+		}
+		s.ExitCode = exit.ExitCode()
+	}
+	s.Stdout, s.Stderr = stdout.String(), stderr.String()
+
+	subprocessMu.Lock()
+	subprocessResults[t.Name()][key] = s
+	subprocessMu.Unlock()
+
+	return s
+}
+
+// recordSubprocesses writes the results of the InSubprocess calls t has made so far to a file for the child to replay, and returns its path.
+func recordSubprocesses(t *testing.T) (string, error) {
+	subprocessMu.Lock()
+	results, ok := subprocessResults[t.Name()]
+	if !ok {
+		results = map[string]*Subprocess{}
+		subprocessResults[t.Name()] = results
+		name := t.Name()
+		t.Cleanup(func() {
+			subprocessMu.Lock()
+			defer subprocessMu.Unlock()
+			delete(subprocessResults, name)
+		})
+	}
+	data, err := json.Marshal(results)
+	subprocessMu.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(t.TempDir(), "subprocesses.json")
+
+	return path, os.WriteFile(path, data, 0o644)
+}
+
+// replaySubprocess returns the result the parent recorded for the InSubprocess call identified by key, if it made that call.
+func replaySubprocess(key string) (*Subprocess, bool) {
+	data, err := os.ReadFile(os.Getenv(subprocessResultsEnv))
+	if err != nil {
+		return nil, false
+	}
+
+	var results map[string]*Subprocess
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, false
+	}
+
+	s, ok := results[key]
+
+	return s, ok
+}
+
+// output renders the child's output for failure messages.
+func (s *Subprocess) output() string {
+	return sprintf("stdout:\n%s\nstderr:\n%s", indent(s.Stdout), indent(s.Stderr))
+}
+
+// indent indents every line of s for inclusion in a failure message.
+func indent(s string) string {
+	if s == "" {
+		return "    <empty>"
+	}
+	return "    " + strings.ReplaceAll(strings.TrimSuffix(s, "\n"), "\n", "\n    ")
+}
+
+// ExitsWith returns a [Predicate] that is ok when the subprocess s exited with code. On failure the child's output is reported.
+func ExitsWith(s *Subprocess, code int) Predicate {
+	return Predicate{
+		ok: func() bool { return s.ExitCode == code },
+		msg: func() string {
+			return sprintf("expected subprocess %q to exit with code %d, got %d\n%s", s.Name, code, s.ExitCode, s.output())
+		},
+		neg: func() string {
+			return sprintf("expected subprocess %q not to exit with code %d\n%s", s.Name, code, s.output())
+		},
+		desc: func() string { return fmt.Sprintf("exits with %d", code) },
+	}
+}
+
+// StdoutContains returns a [Predicate] that is ok when the subprocess s wrote substr to its standard output. On failure the child's output is reported.
+func StdoutContains(s *Subprocess, substr string) Predicate {
+	return Predicate{
+		ok: func() bool { return strings.Contains(s.Stdout, substr) },
+		msg: func() string {
+			return sprintf("expected stdout of subprocess %q to contain %q\n%s", s.Name, substr, s.output())
+		},
+		desc: func() string { return fmt.Sprintf("stdout contains %q", substr) },
+	}
+}
+
+// StderrContains returns a [Predicate] that is ok when the subprocess s wrote substr to its standard error, where log.Fatal writes by default. On failure the child's output is reported.
+func StderrContains(s *Subprocess, substr string) Predicate {
+	return Predicate{
+		ok: func() bool { return strings.Contains(s.Stderr, substr) },
+		msg: func() string {
+			return sprintf("expected stderr of subprocess %q to contain %q\n%s", s.Name, substr, s.output())
+		},
+		desc: func() string { return fmt.Sprintf("stderr contains %q", substr) },
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestInSubprocess(t *testing.T) {
+	exit := observable.InSubprocess(t, "exit", func() {
+		fmt.Println("shutting down")
+		os.Exit(3)
+	})
+	testspy.ExpectPass(t, observable.ExitsWith(exit, 3))
+	testspy.ExpectPass(t, observable.StdoutContains(exit, "shutting down"))
+	testspy.ExpectFail(t, observable.StderrContains(exit, "shutting down"))
+
+	fatal := observable.InSubprocess(t, "fatal", func() { log.Fatal("config missing") })
+	testspy.ExpectPass(t, observable.ExitsWith(fatal, 1))
+	testspy.ExpectPass(t, observable.StderrContains(fatal, "config missing"))
+
+	clean := observable.InSubprocess(t, "clean", func() {})
+	testspy.ExpectPass(t, observable.ExitsWith(clean, 0))
+
+	if msg := observable.ExitsWith(clean, 2).Message(); !strings.Contains(msg, "got 0\nstdout:\n    <empty>") {
+		t.Errorf("unexpected message: %q", msg)
+	}
+}
+
+func TestInSubprocessReplaysEarlierResults(t *testing.T) {
+	o := observable.Expect(t)
+
+	first := observable.InSubprocess(t, "first", func() { os.Exit(3) })
+	// In the child running "second", this must see the real exit code rather than stop the test.
+	o.Require(observable.ExitsWith(first, 3))
+
+	second := observable.InSubprocess(t, "second", func() {
+		fmt.Println("second ran")
+		os.Exit(5)
+	})
+	testspy.ExpectPass(t, observable.ExitsWith(second, 5))
+	testspy.ExpectPass(t, observable.StdoutContains(second, "second ran"))
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import "testing"
+
+// Case is a single case of a table-driven test run by [RunTable].
+type Case struct {
+	// Name names the case's subtest. Cases without a name are numbered by [testing.T.Run] and their failures are prefixed with the [Predicate.Describe] of the failing predicate.
+	Name string
+	// Parallel runs the case in parallel with the other parallel cases of the table.
+	Parallel bool
+	// Setup, if set, prepares the case before Check is called. It may register cleanups on t.
+	Setup func(t *testing.T)
+	// Check returns the predicates that must hold for the case.
+	Check func(t *testing.T) []Predicate
+}
+
+// RunTable runs each case as a subtest of t: it calls the case's Setup, then asserts every predicate returned by its Check. Parallel cases call [testing.T.Parallel] before Setup. A case without a Check fails.
+func RunTable(t *testing.T, cases []Case) {
+	t.Helper()
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Helper()
+
+			if c.Parallel {
+				t.Parallel()
+			}
+			if c.Setup != nil {
+				c.Setup(t)
+			}
+			if c.Check == nil {
+				t.Error("observable: RunTable: case has no Check")
+				return
+			}
+
+			for _, p := range c.Check(t) {
+				if c.Name == "" {
+					With(t, "%s", p.Describe()).That(p)
+				} else {
+					Assert(t, p)
+				}
+			}
+		})
+	}
+}
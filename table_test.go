@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestRunTable(t *testing.T) {
+	var setups int
+
+	observable.RunTable(t, []observable.Case{
+		{
+			Name:  "upper",
+			Setup: func(t *testing.T) { setups++ },
+			Check: func(t *testing.T) []observable.Predicate {
+				return []observable.Predicate{
+					observable.Equal(strings.ToUpper("go"), "GO"),
+					observable.Length([]rune("GO"), 2),
+				}
+			},
+		},
+		{
+			Parallel: true,
+			Check: func(t *testing.T) []observable.Predicate {
+				return []observable.Predicate{observable.HasPrefix("gopher", "go")}
+			},
+		},
+	})
+
+	if setups != 1 {
+		t.Errorf("expected Setup to run once, got %d", setups)
+	}
+}
+
+// tableFailuresEnv makes TestRunTableFailures run its failing table instead of checking the output of a child that does.
+const tableFailuresEnv = "OBSERVABLE_TEST_TABLE_FAILURES"
+
+func TestRunTableFailures(t *testing.T) {
+	if os.Getenv(tableFailuresEnv) != "" {
+		observable.RunTable(t, []observable.Case{
+			{
+				Name: "upper",
+				Check: func(t *testing.T) []observable.Predicate {
+					return []observable.Predicate{observable.Equal(strings.ToUpper("go"), "Go")}
+				},
+			},
+			{
+				Check: func(t *testing.T) []observable.Predicate {
+					return []observable.Predicate{observable.HasPrefix("gopher", "java")}
+				},
+			},
+			{Name: "unchecked"},
+		})
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestRunTableFailures$", "-test.v", "-test.count=1")
+	cmd.Env = append(os.Environ(), tableFailuresEnv+"=1")
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected the table to fail:\n%s", out)
+	}
+
+	output := string(out)
+	testspy.ExpectPass(t, observable.ContainsSubstring(output, "--- FAIL: TestRunTableFailures/upper"))
+	testspy.ExpectPass(t, observable.ContainsSubstring(output, "--- FAIL: TestRunTableFailures/#00"))
+	testspy.ExpectPass(t, observable.ContainsSubstring(output, `has prefix "java": expected`))
+	testspy.ExpectPass(t, observable.ContainsSubstring(output, "--- FAIL: TestRunTableFailures/unchecked"))
+	testspy.ExpectPass(t, observable.ContainsSubstring(output, "observable: RunTable: case has no Check"))
+	testspy.ExpectFail(t, observable.ContainsSubstring(output, "upper: "))
+}
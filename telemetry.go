@@ -0,0 +1,221 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TelemetryOption configures the telemetry recorded after [EnableTelemetry].
+type TelemetryOption func(*telemetryConfig)
+
+type telemetryConfig struct {
+	nearTimeout float64
+}
+
+// NearTimeout sets the fraction of its timeout below which the remaining margin of a passing [Eventually] assertion is flagged in telemetry reports. The default is 0.2.
+func NearTimeout(fraction float64) TelemetryOption {
+	return func(c *telemetryConfig) { c.nearTimeout = fraction }
+}
+
+// PollStats summarizes the [Eventually] assertions made at one call site in one test while telemetry is enabled (see [EnableTelemetry]).
+type PollStats struct {
+	// Test is the name of the test the assertions ran in.
+	Test string
+	// File and Line locate the assertion in the calling code.
+	File string
+	Line int
+	// Timeout is the timeout of the most recent assertion.
+	Timeout time.Duration
+	// Runs is the number of assertions made and Failures the number that timed out.
+	Runs, Failures int
+	// Attempts is the total number of probes across all runs and MaxAttempts the largest number in a single run.
+	Attempts, MaxAttempts int
+	// MinMargin is the smallest time left before the timeout in a passing run, or -1 if no run passed.
+	MinMargin time.Duration
+}
+
+// nearTimeout reports whether a passing run came within fraction of its timeout.
+func (s PollStats) nearTimeout(fraction float64) bool {
+	return s.MinMargin >= 0 && float64(s.MinMargin) < fraction*float64(s.Timeout)
+}
+
+type pollSite struct {
+	test, file string
+	line       int
+}
+
+var (
+	telemetryMu sync.Mutex
+	// telemetry is nil while telemetry is disabled.
+	telemetry       map[pollSite]*PollStats
+	telemetryConf   telemetryConfig
+	telemetryTested map[string]bool
+)
+
+// EnableTelemetry starts recording retry counts and remaining margins of [Eventually] assertions, per test and call site, so that creeping slowness can be spotted before assertions start timing out. Telemetry is opt-in and typically enabled in TestMain; [DisableTelemetry] turns it off again. When a test that made recorded assertions finishes, a summary of its call sites is written to telemetry.txt in its artifact directory (see [SetMaxInlineMessage]); [WriteTelemetryReport] writes a summary of the whole run, e.g. after [testing.M.Run].
+//
+// Only predicates returned by Eventually directly, possibly [Named] or given an ID, are recorded; they must be asserted, e.g. with [Assert], for the test to be known.
+func EnableTelemetry(opts ...TelemetryOption) {
+	telemetryMu.Lock()
+	defer telemetryMu.Unlock()
+
+	telemetryConf = telemetryConfig{nearTimeout: 0.2}
+	for _, opt := range opts {
+		opt(&telemetryConf)
+	}
+	if telemetry == nil {
+		telemetry = map[pollSite]*PollStats{}
+		telemetryTested = map[string]bool{}
+	}
+}
+
+// DisableTelemetry stops recording [Eventually] telemetry and discards the statistics recorded so far, undoing [EnableTelemetry]; a later call to EnableTelemetry starts afresh. Tests that enable telemetry can restore the default with t.Cleanup(DisableTelemetry).
+func DisableTelemetry() {
+	telemetryMu.Lock()
+	defer telemetryMu.Unlock()
+
+	telemetry = nil
+	telemetryTested = nil
+	telemetryConf = telemetryConfig{}
+}
+
+// recordPoll records the result of an asserted [Eventually] if telemetry is enabled.
+func recordPoll(tb testing.TB, timeout time.Duration, res pollResult) {
+	telemetryMu.Lock()
+	enabled := telemetry != nil
+	telemetryMu.Unlock()
+
+	if !enabled {
+		return
+	}
+
+	file, line := callerLocation()
+	site := pollSite{tb.Name(), file, line}
+
+	telemetryMu.Lock()
+	defer telemetryMu.Unlock()
+
+	if !telemetryTested[site.test] {
+		telemetryTested[site.test] = true
+		tb.Cleanup(func() { writeTestTelemetry(tb) })
+	}
+
+	s := telemetry[site]
+	if s == nil {
+		s = &PollStats{Test: site.test, File: file, Line: line, MinMargin: -1}
+		telemetry[site] = s
+	}
+
+	s.Timeout = timeout
+	s.Runs++
+	s.Attempts += res.attempts
+	if res.attempts > s.MaxAttempts {
+		s.MaxAttempts = res.attempts
+	}
+	if !res.ok {
+		s.Failures++
+		return
+	}
+	if margin := timeout - res.elapsed; s.MinMargin < 0 || margin < s.MinMargin {
+		s.MinMargin = margin
+	}
+}
+
+// TelemetryStats returns the statistics recorded since [EnableTelemetry] was called, ordered by test name and location.
+func TelemetryStats() []PollStats {
+	telemetryMu.Lock()
+	defer telemetryMu.Unlock()
+
+	stats := make([]PollStats, 0, len(telemetry))
+	for _, s := range telemetry {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.Test != b.Test {
+			return a.Test < b.Test
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+
+	return stats
+}
+
+// WriteTelemetryReport writes a summary of the recorded [Eventually] telemetry to w, one line per test and call site. Call sites that timed out or whose smallest margin was below the [NearTimeout] fraction of the timeout are listed first.
+func WriteTelemetryReport(w io.Writer) error {
+	return writeTelemetry(w, TelemetryStats())
+}
+
+// writeTestTelemetry writes the telemetry summary of the call sites in tb to its artifact directory.
+func writeTestTelemetry(tb testing.TB) {
+	var stats []PollStats
+	for _, s := range TelemetryStats() {
+		if s.Test == tb.Name() {
+			stats = append(stats, s)
+		}
+	}
+
+	var sb strings.Builder
+	if err := writeTelemetry(&sb, stats); err != nil {
+		tb.Logf("observable: telemetry: %v", err)
+		return
+	}
+
+	dir, err := artifactDir(tb)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, "telemetry.txt"), []byte(sb.String()), 0o644)
+	}
+	if err != nil {
+		tb.Logf("observable: telemetry: %v", err)
+	}
+}
+
+// writeTelemetry implements [WriteTelemetryReport] for stats.
+func writeTelemetry(w io.Writer, stats []PollStats) error {
+	telemetryMu.Lock()
+	fraction := telemetryConf.nearTimeout
+	telemetryMu.Unlock()
+
+	var flagged, rest []PollStats
+	for _, s := range stats {
+		if s.Failures > 0 || s.nearTimeout(fraction) {
+			flagged = append(flagged, s)
+		} else {
+			rest = append(rest, s)
+		}
+	}
+
+	for _, s := range append(flagged, rest...) {
+		margin := "none passed"
+		if s.MinMargin >= 0 {
+			margin = fmt.Sprintf("min margin %v of %v", s.MinMargin.Round(time.Millisecond), s.Timeout)
+		}
+
+		flag := ""
+		switch {
+		case s.Failures > 0:
+			flag = "  TIMED OUT"
+		case s.nearTimeout(fraction):
+			flag = "  NEAR TIMEOUT"
+		}
+
+		if _, err := fmt.Fprintf(w, "%s (%s:%d): %d runs, %d failures, %d attempts (max %d), %s%s\n",
+			s.Test, filepath.Base(s.File), s.Line, s.Runs, s.Failures, s.Attempts, s.MaxAttempts, margin, flag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestTelemetry(t *testing.T) {
+	observable.EnableTelemetry()
+	t.Cleanup(observable.DisableTelemetry)
+
+	spy := testspy.New(t)
+	for i := 0; i < 3; i++ {
+		n := 0
+		observable.Assert(spy, observable.Eventually(time.Second, time.Millisecond, func() observable.Predicate {
+			n++
+			return observable.That(n == 2)
+		}))
+	}
+	observable.Assert(spy, observable.Eventually(5*time.Millisecond, time.Millisecond, observable.False))
+
+	var stats []observable.PollStats
+	for _, s := range observable.TelemetryStats() {
+		if s.Test == t.Name() {
+			stats = append(stats, s)
+		}
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected two call sites, got %+v", stats)
+	}
+	if s := stats[0]; s.Runs != 3 || s.Attempts != 6 || s.MaxAttempts != 2 || s.Failures != 0 || s.MinMargin <= 0 {
+		t.Errorf("unexpected stats for passing site: %+v", s)
+	}
+	if s := stats[1]; s.Runs != 1 || s.Failures != 1 || s.MinMargin != -1 {
+		t.Errorf("unexpected stats for failing site: %+v", s)
+	}
+
+	var sb strings.Builder
+	if err := observable.WriteTelemetryReport(&sb); err != nil {
+		t.Fatal(err)
+	}
+	testspy.ExpectPass(t, observable.ContainsSubstring(sb.String(), "1 runs, 1 failures"))
+	testspy.ExpectPass(t, observable.ContainsSubstring(sb.String(), "none passed  TIMED OUT"))
+}
+
+func TestDisableTelemetry(t *testing.T) {
+	observable.EnableTelemetry()
+	observable.Assert(t, observable.Eventually(time.Second, time.Millisecond, observable.True))
+	observable.DisableTelemetry()
+
+	if stats := observable.TelemetryStats(); len(stats) != 0 {
+		t.Errorf("expected disabling to discard statistics, got %+v", stats)
+	}
+
+	observable.Assert(t, observable.Eventually(time.Second, time.Millisecond, observable.True))
+	if stats := observable.TelemetryStats(); len(stats) != 0 {
+		t.Errorf("expected nothing to be recorded while disabled, got %+v", stats)
+	}
+}
+
+// artifactTB directs artifacts to dir.
+type artifactTB struct {
+	*testspy.SpyTB
+	dir string
+}
+
+func (a artifactTB) ArtifactDir() string { return a.dir }
+
+func TestTelemetryArtifact(t *testing.T) {
+	observable.EnableTelemetry(observable.NearTimeout(1))
+	t.Cleanup(observable.DisableTelemetry)
+
+	dir := t.TempDir()
+	t.Run("poll", func(t *testing.T) {
+		tb := artifactTB{testspy.New(t), dir}
+		observable.Assert(tb, observable.Eventually(time.Second, time.Millisecond, observable.True))
+	})
+
+	data, err := os.ReadFile(filepath.Join(dir, "telemetry.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testspy.ExpectPass(t, observable.HasPrefix(string(data), "TestTelemetryArtifact/poll (telemetry_test.go:"))
+	testspy.ExpectPass(t, observable.ContainsSubstring(string(data), "1 runs, 0 failures, 1 attempts (max 1)"))
+	testspy.ExpectPass(t, observable.ContainsSubstring(string(data), "  NEAR TIMEOUT\n"))
+}
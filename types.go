@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// IsType returns a [Predicate] that is ok when the dynamic type of v is exactly T.
+func IsType[T any](v any) Predicate {
+	return Predicate{
+		ok:  memo(func() bool { return reflect.TypeOf(v) == typeOf[T]() }),
+		msg: func() string { return sprintf("expected type %v, got %T", typeOf[T](), v) },
+	}
+}
+
+// Implements returns a [Predicate] that is ok when v implements the interface I. Implements **panics** if I is not an interface type.
+func Implements[I any](v any) Predicate {
+	if typeOf[I]().Kind() != reflect.Interface {
+		panic(fmt.Sprintf("Implements requires an interface type, got %v", typeOf[I]()))
+	}
+
+	return Predicate{
+		ok:  memo(func() bool { _, ok := v.(I); return ok }),
+		msg: func() string { return sprintf("expected %T to implement %v", v, typeOf[I]()) },
+	}
+}
+
+// Kind returns a [Predicate] that is ok when v's dynamic type is of kind k.
+func Kind(v any, k reflect.Kind) Predicate {
+	return Predicate{
+		ok:  memo(func() bool { return reflect.ValueOf(v).Kind() == k }),
+		msg: func() string { return sprintf("expected kind %v, got %v (%T)", k, reflect.ValueOf(v).Kind(), v) },
+	}
+}
+
+// typeOf returns the [reflect.Type] of T, including when T is an interface type.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
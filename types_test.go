@@ -0,0 +1,33 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestTypeChecks(t *testing.T) {
+	testspy.ExpectPass(t, observable.IsType[int](1))
+	testspy.ExpectFail(t, observable.IsType[int64](1))
+	testspy.ExpectFail(t, observable.IsType[error](errFoo))
+	testspy.ExpectFail(t, observable.IsType[int](nil))
+
+	testspy.ExpectPass(t, observable.Implements[error](errFoo))
+	testspy.ExpectPass(t, observable.Implements[fmt.Stringer](reflect.Int))
+	testspy.ExpectFail(t, observable.Implements[fmt.Stringer](1))
+	testspy.ExpectPass(t, observable.Panics(func() { observable.Implements[int](1) }))
+
+	testspy.ExpectPass(t, observable.Kind([]int{}, reflect.Slice))
+	testspy.ExpectFail(t, observable.Kind(errors.New("x"), reflect.Struct))
+
+	if msg := observable.IsType[int64](1).Message(); !strings.Contains(msg, "int64") || !strings.Contains(msg, "got int") {
+		t.Errorf("expected message to name both types, got %q", msg)
+	}
+}
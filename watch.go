@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"sync"
+	"time"
+)
+
+// WatchOption configures [EmitsFileEvent].
+type WatchOption func(*watchConfig)
+
+type watchConfig struct {
+	debounce time.Duration
+	maxSeen  int
+}
+
+// Debounce makes [EmitsFileEvent] keep consuming events after the match until none has arrived for d, so that the burst of events watchers commonly emit for a single change does not leak into later assertions.
+func Debounce(d time.Duration) WatchOption {
+	return func(c *watchConfig) { c.debounce = d }
+}
+
+// ReportEvents sets how many of the non-matching events received are listed on failure. The default is 5.
+func ReportEvents(n int) WatchOption {
+	return func(c *watchConfig) { c.maxSeen = n }
+}
+
+// EmitsFileEvent returns a [Predicate] that is ok when an event for which match returns an ok predicate is received from events within timeout. Events that do not match are consumed and skipped; on failure the most recent ones are reported together with the last match message. It is intended for fsnotify-style watchers but works with any event type.
+//
+// The receives happen at most once, on first evaluation.
+func EmitsFileEvent[E any](events <-chan E, timeout time.Duration, match func(E) Predicate, opts ...WatchOption) Predicate {
+	cfg := watchConfig{maxSeen: 5}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		once    sync.Once
+		matched bool
+		closed  bool
+		seen    []E
+		skipped int
+		last    Predicate
+	)
+
+	eval := func() {
+		once.Do(func() {
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+
+			for !matched {
+				select {
+				case e, ok := <-events:
+					if !ok {
+						closed = true
+						return
+					}
+					if last = match(e); last.Ok() {
+						matched = true
+						break
+					}
+					skipped++
+					if seen = append(seen, e); len(seen) > cfg.maxSeen {
+						seen = seen[1:]
+					}
+				case <-timer.C:
+					return
+				}
+			}
+
+			if cfg.debounce <= 0 {
+				return
+			}
+			quiet := time.NewTimer(cfg.debounce)
+			defer quiet.Stop()
+			for {
+				select {
+				case _, ok := <-events:
+					if !ok {
+						return
+					}
+					quiet.Reset(cfg.debounce)
+				case <-quiet.C:
+					return
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return matched },
+		msg: func() string {
+			eval()
+
+			end := sprintf("within %v", timeout)
+			if closed {
+				end = "before the event channel was closed"
+			}
+			if skipped == 0 {
+				return sprintf("expected matching file event %s, got no events", end)
+			}
+			return sprintf("expected matching file event %s, got %d non-matching events; last %d: %+v\nlast mismatch: %s", end, skipped, len(seen), seen, last.Message())
+		},
+		desc: func() string { return "emits file event" },
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+type fileEvent struct {
+	Name string
+	Op   string
+}
+
+func TestEmitsFileEvent(t *testing.T) {
+	write := func(e fileEvent) observable.Predicate { return observable.Equal(e, fileEvent{"a.txt", "WRITE"}) }
+
+	events := make(chan fileEvent, 10)
+	events <- fileEvent{"a.txt", "CREATE"}
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		events <- fileEvent{"a.txt", "WRITE"}
+		events <- fileEvent{"a.txt", "WRITE"}
+		events <- fileEvent{"a.txt", "CHMOD"}
+	}()
+
+	testspy.ExpectPass(t, observable.EmitsFileEvent(events, time.Second, write, observable.Debounce(20*time.Millisecond)))
+	testspy.ExpectPass(t, observable.ChanLength(events, 0))
+
+	events <- fileEvent{"b.txt", "WRITE"}
+	p := observable.EmitsFileEvent(events, 10*time.Millisecond, write)
+	testspy.ExpectFail(t, p)
+	if msg := p.Message(); !strings.Contains(msg, "got 1 non-matching events; last 1: [{Name:b.txt Op:WRITE}]") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+
+	close(events)
+	testspy.ExpectFail(t, observable.EmitsFileEvent(events, time.Second, write))
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Write is a single call to [SpyWriter.Write].
+type Write struct {
+	Data []byte
+	At   time.Time
+}
+
+// SpyWriter is an [io.Writer] test double that records every write with its time, for checking write batching with [WroteTotal], [WroteContaining] and [WroteInChunksOfAtMost]. Its zero value is ready to use, and it is safe for concurrent use.
+type SpyWriter struct {
+	mu     sync.Mutex
+	writes []Write
+}
+
+// Write records a copy of p and reports it as fully written.
+func (w *SpyWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.writes = append(w.writes, Write{Data: append([]byte(nil), p...), At: time.Now()})
+
+	return len(p), nil
+}
+
+// Writes returns the writes recorded so far, in order.
+func (w *SpyWriter) Writes() []Write {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]Write(nil), w.writes...)
+}
+
+// Bytes returns the concatenation of the writes recorded so far.
+func (w *SpyWriter) Bytes() []byte {
+	var b []byte
+	for _, wr := range w.Writes() {
+		b = append(b, wr.Data...)
+	}
+	return b
+}
+
+// WroteTotal returns a [Predicate] that is ok when the writes recorded by w add up to exactly n bytes. The writes are inspected on first evaluation.
+func WroteTotal(w *SpyWriter, n int) Predicate {
+	var (
+		once   sync.Once
+		writes int
+		total  int
+	)
+
+	eval := func() {
+		once.Do(func() {
+			ws := w.Writes()
+			writes = len(ws)
+			for _, wr := range ws {
+				total += len(wr.Data)
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return total == n },
+		msg: func() string {
+			eval()
+			return sprintf("expected %d bytes written, got %d in %d writes", n, total, writes)
+		},
+		desc: func() string { return fmt.Sprintf("wrote %d bytes", n) },
+	}
+}
+
+// WroteContaining returns a [Predicate] that is ok when the bytes written to w contain substr, which may span several writes. The writes are inspected on first evaluation.
+func WroteContaining(w *SpyWriter, substr string) Predicate {
+	var (
+		once sync.Once
+		data []byte
+	)
+
+	eval := func() { once.Do(func() { data = w.Bytes() }) }
+
+	return Predicate{
+		ok: func() bool { eval(); return bytes.Contains(data, []byte(substr)) },
+		msg: func() string {
+			eval()
+			return sprintf("expected output to contain %q, got %s", substr, preview(string(data)))
+		},
+		neg: func() string {
+			eval()
+			return sprintf("expected output not to contain %q, got %s", substr, preview(string(data)))
+		},
+		desc: func() string { return fmt.Sprintf("wrote %q", substr) },
+	}
+}
+
+// WroteInChunksOfAtMost returns a [Predicate] that is ok when none of the writes recorded by w was longer than n bytes. On failure the oversized writes are listed with their index and length. The writes are inspected on first evaluation.
+func WroteInChunksOfAtMost(w *SpyWriter, n int) Predicate {
+	var (
+		once      sync.Once
+		writes    int
+		oversized []string
+	)
+
+	eval := func() {
+		once.Do(func() {
+			ws := w.Writes()
+			writes = len(ws)
+			for i, wr := range ws {
+				if len(wr.Data) > n {
+					oversized = append(oversized, sprintf("[%d]: %d bytes", i, len(wr.Data)))
+				}
+			}
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return len(oversized) == 0 },
+		msg: func() string {
+			eval()
+			return sprintf("expected writes of at most %d bytes, %d of %d writes were larger: %s", n, len(oversized), writes, strings.Join(oversized, ", "))
+		},
+		desc: func() string { return fmt.Sprintf("writes <= %d bytes", n) },
+	}
+}
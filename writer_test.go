@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"bufio"
+	"fmt"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestSpyWriter(t *testing.T) {
+	var w observable.SpyWriter
+
+	bw := bufio.NewWriterSize(&w, 16)
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(bw, "line %d\n", i)
+	}
+	if err := bw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	testspy.ExpectPass(t, observable.WroteTotal(&w, 70))
+	testspy.ExpectFail(t, observable.WroteTotal(&w, 7))
+	testspy.ExpectPass(t, observable.WroteContaining(&w, "line 3\nline 4"))
+	testspy.ExpectFail(t, observable.WroteContaining(&w, "line 10"))
+	testspy.ExpectPass(t, observable.WroteInChunksOfAtMost(&w, 16))
+	testspy.ExpectFail(t, observable.WroteInChunksOfAtMost(&w, 8))
+
+	if n := len(w.Writes()); n != 5 {
+		t.Errorf("got %d writes, want 5", n)
+	}
+	for i, wr := range w.Writes() {
+		if wr.At.IsZero() {
+			t.Errorf("write %d has no timestamp", i)
+		}
+	}
+
+	var small observable.SpyWriter
+	small.Write([]byte("abc"))
+	small.Write([]byte("defghij"))
+	for _, tc := range []struct {
+		p    observable.Predicate
+		want string
+	}{
+		{observable.WroteTotal(&small, 3), "expected 3 bytes written, got 10 in 2 writes"},
+		{observable.WroteContaining(&small, "xyz"), `expected output to contain "xyz", got "abcdefghij"`},
+		{observable.WroteInChunksOfAtMost(&small, 2), "expected writes of at most 2 bytes, 2 of 2 writes were larger: [0]: 3 bytes, [1]: 7 bytes"},
+	} {
+		if msg := tc.p.Message(); msg != tc.want {
+			t.Errorf("got message %q, want %q", msg, tc.want)
+		}
+	}
+}
@@ -0,0 +1,660 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// YAMLEqual returns a [Predicate] that is ok when the YAML documents got and want are semantically equal: mappings are compared regardless of key order, and formatting, comments and quoting style are ignored. On failure the path to the first difference is reported, as for [DeepEqual].
+//
+// To stay dependency-free only a commonly used subset of YAML is understood: block and flow mappings and sequences, plain and quoted scalars, and literal (|) and folded (>) block scalars. Documents using anchors, aliases, tags or multiple documents are reported as invalid.
+func YAMLEqual(got, want string) Predicate {
+	var (
+		once sync.Once
+		diff string
+		err  error
+	)
+
+	eval := func() {
+		once.Do(func() {
+			g, gerr := parseYAML(got)
+			if gerr != nil {
+				err = errorf("invalid YAML in got: %w", gerr)
+				return
+			}
+			w, werr := parseYAML(want)
+			if werr != nil {
+				err = errorf("invalid YAML in want: %w", werr)
+				return
+			}
+			// .nan in both documents is the same value.
+			nan := Comparer(func(got, want float64) bool { return got == want || math.IsNaN(got) && math.IsNaN(want) })
+			diff = newDiffer([]Option{nan}).diff(reflect.ValueOf(g), reflect.ValueOf(w))
+		})
+	}
+
+	return Predicate{
+		ok: func() bool { eval(); return err == nil && diff == "" },
+		msg: func() string {
+			eval()
+			if err != nil {
+				return sprintf("expected YAML documents to be equal: %v", err)
+			}
+			return sprintf("expected YAML documents to be equal\n%s", diff)
+		},
+		desc:   func() string { return "YAML equal" },
+		values: func() (any, any) { return got, want },
+	}
+}
+
+// yamlLine is a single line of a YAML document.
+type yamlLine struct {
+	num    int    // 1-based line number
+	raw    string // the line as written
+	indent int    // number of leading spaces
+	text   string // the line without indentation and comments; "" for blank lines
+}
+
+// yamlParser parses the YAML subset understood by [YAMLEqual] into map[string]any, []any, string, int64, float64, bool and nil values.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML parses a single YAML document.
+func parseYAML(doc string) (any, error) {
+	p := &yamlParser{}
+
+	for i, raw := range strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n") {
+		l := yamlLine{num: i + 1, raw: raw}
+		trimmed := strings.TrimLeft(raw, " ")
+		l.indent = len(raw) - len(trimmed)
+		l.text = strings.TrimSpace(stripYAMLComment(trimmed))
+		p.lines = append(p.lines, l)
+	}
+
+	// Drop document markers around a single document.
+	p.skipBlank()
+	if p.pos < len(p.lines) && p.lines[p.pos].indent == 0 && p.lines[p.pos].text == "---" {
+		p.pos++
+	}
+	for i := p.pos; i < len(p.lines); i++ {
+		if l := p.lines[i]; l.indent == 0 && (l.text == "---" || l.text == "...") {
+			for _, rest := range p.lines[i+1:] {
+				if rest.text != "" {
+					return nil, p.errorf(rest, "multiple documents are not supported")
+				}
+			}
+			p.lines = p.lines[:i]
+			break
+		}
+	}
+
+	v, err := p.parseNode(0)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipBlank()
+	if p.pos < len(p.lines) {
+		return nil, p.errorf(p.lines[p.pos], "unexpected indentation")
+	}
+
+	return v, nil
+}
+
+// stripYAMLComment removes a trailing comment from s, ignoring # characters inside quoted scalars or not preceded by whitespace.
+func stripYAMLComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '\'' && c == '\'':
+			quote = 0
+		case quote == '"' && c == '\\':
+			i++
+		case quote == '"' && c == '"':
+			quote = 0
+		case quote != 0:
+		case (c == '"' || c == '\'') && (i == 0 || strings.ContainsRune(" \t[{,:-", rune(s[i-1]))):
+			quote = c
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func (p *yamlParser) errorf(l yamlLine, format string, args ...any) error {
+	return errorf("line %d: %s", l.num, sprintf(format, args...))
+}
+
+// skipBlank advances past blank and comment-only lines.
+func (p *yamlParser) skipBlank() {
+	for p.pos < len(p.lines) && p.lines[p.pos].text == "" {
+		p.pos++
+	}
+}
+
+// parseNode parses the node starting at the next non-blank line, which must be indented by at least minIndent. A missing node is null.
+func (p *yamlParser) parseNode(minIndent int) (any, error) {
+	p.skipBlank()
+	if p.pos >= len(p.lines) || p.lines[p.pos].indent < minIndent {
+		return nil, nil
+	}
+
+	l := p.lines[p.pos]
+	if strings.HasPrefix(l.raw, "\t") || strings.HasPrefix(l.raw[l.indent:], "\t") {
+		return nil, p.errorf(l, "tabs are not allowed in indentation")
+	}
+
+	switch {
+	case isYAMLSeqItem(l.text):
+		return p.parseSeq(l.indent)
+	case yamlKeyEnd(l.text) >= 0:
+		return p.parseMap(l.indent)
+	}
+
+	p.pos++
+	if l.text[0] == '|' || l.text[0] == '>' {
+		return p.parseBlockScalar(l, l.text, minIndent-1)
+	}
+	return p.parseScalar(l, l.text)
+}
+
+// isYAMLSeqItem reports whether text starts a block sequence item.
+func isYAMLSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// yamlKeyEnd returns the index of the colon ending the mapping key that starts text, or -1 if text is not a mapping entry.
+func yamlKeyEnd(text string) int {
+	if text == "" || strings.ContainsRune("[{", rune(text[0])) {
+		return -1
+	}
+
+	// Skip a quoted key, which may itself contain colons.
+	i := 0
+	if q := text[0]; q == '"' || q == '\'' {
+		for i = 1; i < len(text) && text[i] != q; i++ {
+			if q == '"' && text[i] == '\\' {
+				i++
+			}
+		}
+		if i >= len(text) {
+			return -1
+		}
+		i++
+	}
+
+	for ; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseSeq parses a block sequence whose items start at indent.
+func (p *yamlParser) parseSeq(indent int) (any, error) {
+	seq := []any{}
+
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			return seq, nil
+		}
+		l := p.lines[p.pos]
+		if l.indent < indent || !isYAMLSeqItem(l.text) {
+			return seq, nil
+		}
+		if l.indent > indent {
+			return nil, p.errorf(l, "unexpected indentation")
+		}
+
+		rest := strings.TrimLeft(strings.TrimPrefix(l.text, "-"), " ")
+		if rest == "" {
+			p.pos++
+		} else {
+			// Reparse the remainder of the line as a node indented past the dash, so that "- key: value" continues as a mapping on the following lines.
+			p.lines[p.pos].indent += len(l.text) - len(rest)
+			p.lines[p.pos].text = rest
+		}
+
+		item, err := p.parseNode(indent + 1)
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, item)
+	}
+}
+
+// parseMap parses a block mapping whose keys start at indent.
+func (p *yamlParser) parseMap(indent int) (any, error) {
+	m := map[string]any{}
+
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			return m, nil
+		}
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			return m, nil
+		}
+		end := yamlKeyEnd(l.text)
+		if l.indent > indent || end < 0 {
+			if l.indent == indent && isYAMLSeqItem(l.text) {
+				return m, nil
+			}
+			return nil, p.errorf(l, "unexpected indentation")
+		}
+
+		key, err := p.parseKey(l, l.text[:end])
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := m[key]; dup {
+			return nil, p.errorf(l, "duplicate key %q", key)
+		}
+
+		p.pos++
+		rest := strings.TrimSpace(l.text[end+1:])
+
+		var v any
+		switch {
+		case rest == "":
+			// A sequence may be indented at the same level as its key.
+			p.skipBlank()
+			if p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLSeqItem(p.lines[p.pos].text) {
+				v, err = p.parseSeq(indent)
+			} else {
+				v, err = p.parseNode(indent + 1)
+			}
+		case rest[0] == '|' || rest[0] == '>':
+			v, err = p.parseBlockScalar(l, rest, indent)
+		default:
+			v, err = p.parseScalar(l, rest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+	}
+}
+
+// parseKey parses a mapping key, which must be a scalar.
+func (p *yamlParser) parseKey(l yamlLine, s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || (s[0] != '"' && s[0] != '\'') {
+		// Plain keys keep their spelling, so "1" and "01" remain distinct keys.
+		return s, nil
+	}
+
+	v, err := p.parseScalar(l, s)
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// parseBlockScalar parses a literal (|) or folded (>) block scalar introduced by header on line l, belonging to a key at indent.
+func (p *yamlParser) parseBlockScalar(l yamlLine, header string, indent int) (any, error) {
+	chomp := byte(0)
+	for _, c := range header[1:] {
+		switch {
+		case c == '-' || c == '+':
+			chomp = byte(c)
+		case c >= '1' && c <= '9':
+			// Explicit indentation indicators are accepted; the content indentation is detected below.
+		default:
+			return nil, p.errorf(l, "invalid block scalar header %q", header)
+		}
+	}
+
+	var (
+		lines         []string
+		contentIndent = -1
+	)
+	for ; p.pos < len(p.lines); p.pos++ {
+		raw := p.lines[p.pos].raw
+		if strings.TrimSpace(raw) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		n := len(raw) - len(strings.TrimLeft(raw, " "))
+		if n <= indent {
+			break
+		}
+		if contentIndent < 0 {
+			contentIndent = n
+		}
+		if n < contentIndent {
+			return nil, p.errorf(p.lines[p.pos], "block scalar line is less indented than its first line")
+		}
+		lines = append(lines, raw[contentIndent:])
+	}
+
+	// Trailing blank lines are handled by chomping.
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+
+	var s string
+	if header[0] == '|' {
+		s = strings.Join(lines, "\n")
+	} else {
+		var sb strings.Builder
+		for i, line := range lines {
+			switch {
+			case i == 0:
+			case line == "" || lines[i-1] == "":
+				sb.WriteByte('\n')
+			case strings.HasPrefix(line, " ") || strings.HasPrefix(lines[i-1], " "):
+				sb.WriteByte('\n')
+			default:
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(line)
+		}
+		s = sb.String()
+	}
+
+	switch {
+	case len(lines) == 0:
+	case chomp == '-':
+	case chomp == '+':
+		s += strings.Repeat("\n", trailing+1)
+	default:
+		s += "\n"
+	}
+
+	return s, nil
+}
+
+// parseScalar parses s, the remainder of line l, as a flow collection or scalar.
+func (p *yamlParser) parseScalar(l yamlLine, s string) (any, error) {
+	f := &yamlFlow{s: s}
+	v, err := f.value()
+	if err == nil {
+		f.space()
+		if f.i < len(f.s) {
+			err = errorf("unexpected %q after value", f.s[f.i:])
+		}
+	}
+	if err != nil {
+		return nil, p.errorf(l, "%v", err)
+	}
+	return v, nil
+}
+
+// yamlFlow parses flow-style values within a single line.
+type yamlFlow struct {
+	s string
+	i int
+}
+
+func (f *yamlFlow) space() {
+	for f.i < len(f.s) && f.s[f.i] == ' ' {
+		f.i++
+	}
+}
+
+// value parses a flow sequence, flow mapping or scalar.
+func (f *yamlFlow) value() (any, error) {
+	f.space()
+	if f.i >= len(f.s) {
+		return nil, nil
+	}
+
+	switch c := f.s[f.i]; c {
+	case '[':
+		return f.seq()
+	case '{':
+		return f.mapping()
+	case '"', '\'':
+		return f.quoted(c)
+	case '&', '*', '!':
+		return nil, errorf("anchors, aliases and tags are not supported")
+	}
+
+	// Plain scalars end at a flow indicator when nested in a flow collection.
+	start := f.i
+	for f.i < len(f.s) && !(f.nested() && strings.ContainsRune(",]}", rune(f.s[f.i]))) {
+		if f.nested() && f.s[f.i] == ':' && (f.i+1 == len(f.s) || strings.ContainsRune(" ,]}", rune(f.s[f.i+1]))) {
+			break
+		}
+		f.i++
+	}
+
+	return resolveYAMLScalar(strings.TrimSpace(f.s[start:f.i])), nil
+}
+
+// nested reports whether the parser is inside a flow collection; only then are flow indicators special in plain scalars.
+func (f *yamlFlow) nested() bool {
+	return f.s != "" && (f.s[0] == '[' || f.s[0] == '{')
+}
+
+func (f *yamlFlow) seq() (any, error) {
+	f.i++ // '['
+	seq := []any{}
+
+	for {
+		f.space()
+		if f.i < len(f.s) && f.s[f.i] == ']' {
+			f.i++
+			return seq, nil
+		}
+		v, err := f.value()
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, v)
+		if err := f.separator(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (f *yamlFlow) mapping() (any, error) {
+	f.i++ // '{'
+	m := map[string]any{}
+
+	for {
+		f.space()
+		if f.i < len(f.s) && f.s[f.i] == '}' {
+			f.i++
+			return m, nil
+		}
+
+		start := f.i
+		k, err := f.value()
+		if err != nil {
+			return nil, err
+		}
+		key := strings.TrimSpace(f.s[start:f.i])
+		if s, ok := k.(string); ok && (key[0] == '"' || key[0] == '\'') {
+			key = s
+		}
+		if _, dup := m[key]; dup {
+			return nil, errorf("duplicate key %q", key)
+		}
+
+		f.space()
+		var v any
+		if f.i < len(f.s) && f.s[f.i] == ':' {
+			f.i++
+			if v, err = f.value(); err != nil {
+				return nil, err
+			}
+		}
+		m[key] = v
+
+		if err := f.separator('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// separator consumes the comma between flow collection entries, or leaves the closing delimiter for the caller.
+func (f *yamlFlow) separator(closing byte) error {
+	f.space()
+	switch {
+	case f.i >= len(f.s):
+		return errorf("unterminated flow collection, expected %q", closing)
+	case f.s[f.i] == ',':
+		f.i++
+		return nil
+	case f.s[f.i] == closing:
+		return nil
+	default:
+		return errorf("unexpected %q in flow collection", f.s[f.i])
+	}
+}
+
+// quoted parses a single- or double-quoted scalar.
+func (f *yamlFlow) quoted(q byte) (any, error) {
+	start := f.i
+	f.i++
+
+	if q == '\'' {
+		var sb strings.Builder
+		for f.i < len(f.s) {
+			if f.s[f.i] == '\'' {
+				if f.i+1 < len(f.s) && f.s[f.i+1] == '\'' {
+					sb.WriteByte('\'')
+					f.i += 2
+					continue
+				}
+				f.i++
+				return sb.String(), nil
+			}
+			sb.WriteByte(f.s[f.i])
+			f.i++
+		}
+		return nil, errorf("unterminated quoted scalar %s", f.s[start:])
+	}
+
+	for f.i < len(f.s) {
+		switch f.s[f.i] {
+		case '\\':
+			f.i += 2
+		case '"':
+			f.i++
+			s, ok := unescapeYAML(f.s[start+1 : f.i-1])
+			if !ok {
+				return nil, errorf("invalid quoted scalar %s", f.s[start:f.i])
+			}
+			return s, nil
+		default:
+			f.i++
+		}
+	}
+	return nil, errorf("unterminated quoted scalar %s", f.s[start:])
+}
+
+// yamlEscapes maps the single-character escapes of double-quoted YAML scalars to the text they stand for.
+var yamlEscapes = map[byte]string{
+	'0': "\x00", 'a': "\a", 'b': "\b", 't': "\t", '\t': "\t", 'n': "\n", 'v': "\v", 'f': "\f", 'r': "\r", 'e': "\x1b",
+	' ': " ", '"': "\"", '/': "/", '\\': "\\", 'N': "\u0085", '_': "\u00a0", 'L': "\u2028", 'P': "\u2029",
+}
+
+// unescapeYAML decodes the escape sequences of the body s of a double-quoted YAML scalar. Unlike [strconv.Unquote] it accepts the YAML-only escapes such as \e, \N and \/, treats \x as a code point rather than a byte and rejects Go's octal escapes. It reports false if s contains an invalid escape.
+func unescapeYAML(s string) (string, bool) {
+	if !strings.Contains(s, "\\") {
+		return s, true
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			sb.WriteByte(s[i])
+			continue
+		}
+		if i++; i == len(s) {
+			return "", false
+		}
+		if e, ok := yamlEscapes[s[i]]; ok {
+			sb.WriteString(e)
+			continue
+		}
+
+		var n int
+		switch s[i] {
+		case 'x':
+			n = 2
+		case 'u':
+			n = 4
+		case 'U':
+			n = 8
+		}
+		if n == 0 || i+n >= len(s) {
+			return "", false
+		}
+		r, err := strconv.ParseUint(s[i+1:i+1+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(r)) {
+			return "", false
+		}
+		sb.WriteRune(rune(r))
+		i += n
+	}
+
+	return sb.String(), true
+}
+
+// The number forms of the YAML 1.2 core schema. Other spellings accepted by strconv, such as "010" as octal or "inf", are strings.
+var (
+	yamlInt   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	yamlOctal = regexp.MustCompile(`^0o[0-7]+$`)
+	yamlHex   = regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
+	yamlFloat = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+)
+
+// resolveYAMLScalar resolves a plain scalar to a value following the YAML 1.2 core schema.
+func resolveYAMLScalar(s string) any {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1)
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1)
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN()
+	}
+
+	switch {
+	case yamlInt.MatchString(s):
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+		f, _ := strconv.ParseFloat(s, 64)
+		return f
+	case yamlOctal.MatchString(s):
+		if i, err := strconv.ParseInt(s[2:], 8, 64); err == nil {
+			return i
+		}
+	case yamlHex.MatchString(s):
+		if i, err := strconv.ParseInt(s[2:], 16, 64); err == nil {
+			return i
+		}
+	case yamlFloat.MatchString(s):
+		f, _ := strconv.ParseFloat(s, 64)
+		return f
+	}
+
+	return s
+}
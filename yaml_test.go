@@ -0,0 +1,98 @@
+// Copyright (c) 2025 Renorm Labs. All rights reserved.
+
+package observable_test
+
+import (
+	"strings"
+	"testing"
+
+	"renorm.dev/observable"
+	"renorm.dev/observable/internal/testspy"
+)
+
+func TestYAMLEqual(t *testing.T) {
+	got := `
+# service config
+name: api
+port: 8080
+tags: [web, "public"]
+limits: {cpu: 2, memory: 1.5}
+servers:
+  - host: a.example.com
+    weight: 1
+  - host: 'b.example.com'
+    weight: 2
+motd: |
+  hello
+  world
+`
+	want := `---
+servers:
+- weight: 1
+  host: a.example.com
+- {host: b.example.com, weight: 2}
+limits:
+  memory: 1.5
+  cpu: 2
+tags:
+  - web
+  - public
+port: 8080   # default
+name: "api"
+motd: "hello\nworld\n"
+`
+
+	testspy.ExpectPass(t, observable.YAMLEqual(got, want))
+	testspy.ExpectPass(t, observable.YAMLEqual("a: >-\n  one\n  two\n", `a: one two`))
+	testspy.ExpectPass(t, observable.YAMLEqual("- - 1\n  - 2\n- ~\n", "[[1, 2], null]"))
+
+	p := observable.YAMLEqual(got, strings.Replace(want, "weight: 2", "weight: 3", 1))
+	testspy.ExpectFail(t, p)
+	if msg := p.Message(); !strings.Contains(msg, `["servers"][1]["weight"]: 2 != 3`) {
+		t.Errorf("unexpected message: %s", msg)
+	}
+
+	testspy.ExpectFail(t, observable.YAMLEqual("port: 8080", `port: "8080"`))
+	testspy.ExpectFail(t, observable.YAMLEqual("a: 1\na: 2", "a: 2"))
+	testspy.ExpectFail(t, observable.YAMLEqual("a: [1, 2", "a: [1, 2]"))
+
+	if msg := observable.YAMLEqual("a: *ref", "a: 1").Message(); !strings.Contains(msg, "invalid YAML in got: line 1") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+}
+
+func TestYAMLEqualCoreSchemaNumbers(t *testing.T) {
+	testspy.ExpectFail(t, observable.YAMLEqual("a: 010", "a: 8"))
+	testspy.ExpectPass(t, observable.YAMLEqual("a: 010", "a: 10"))
+	testspy.ExpectPass(t, observable.YAMLEqual("a: 0o10", "a: 8"))
+	testspy.ExpectPass(t, observable.YAMLEqual("a: 0x1F", "a: 31"))
+	testspy.ExpectPass(t, observable.YAMLEqual("a: 1e3", "a: 1000.0"))
+	testspy.ExpectPass(t, observable.YAMLEqual("a: +12", "a: 12"))
+
+	testspy.ExpectFail(t, observable.YAMLEqual("a: inf", "a: .inf"))
+	testspy.ExpectFail(t, observable.YAMLEqual("a: nan", "a: .nan"))
+	testspy.ExpectPass(t, observable.YAMLEqual("a: inf", `a: "inf"`))
+	testspy.ExpectPass(t, observable.YAMLEqual("a: -.inf", "a: -.Inf"))
+
+	testspy.ExpectPass(t, observable.YAMLEqual("a: .nan", "a: .NaN"))
+	testspy.ExpectPass(t, observable.YAMLEqual("a: [.nan, 1]", "a: [.nan, 1]"))
+	testspy.ExpectFail(t, observable.YAMLEqual("a: .nan", "a: 1.0"))
+}
+
+func TestYAMLEqualEscapes(t *testing.T) {
+	for got, want := range map[string]string{
+		`a: "\/\e\ \_"`:                 "a: \"/\x1b \u00a0\"",
+		`a: "\N\L\P"`:                   "a: \"\u0085\u2028\u2029\"",
+		`a: "\x41\xe9\u00e9\U0001F600"`: "a: Aéé😀",
+		`a: "tab\	end"`:                 "a: \"tab\tend\"",
+		`a: "q\"\\"`:                    `a: 'q"\'`,
+	} {
+		testspy.ExpectPass(t, observable.YAMLEqual(got, want))
+	}
+
+	for _, invalid := range []string{`a: "\101"`, `a: "\q"`, `a: "\x4"`, `a: "\ud800"`, `a: "\U00110000"`} {
+		if msg := observable.YAMLEqual(invalid, "a: A").Message(); !strings.Contains(msg, "invalid quoted scalar") {
+			t.Errorf("expected %s to be invalid, got %s", invalid, msg)
+		}
+	}
+}